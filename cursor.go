@@ -0,0 +1,146 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCursorMalformed is returned when a cursor cannot be decoded or its signature does not match.
+	ErrCursorMalformed = errors.New("Cursor is malformed")
+	// ErrCursorExpired is returned when a cursor is older than the schema cursor time to live.
+	ErrCursorExpired = errors.New("Cursor has expired")
+	// ErrCursorFilters is returned when a cursor was issued for a different set of filters.
+	ErrCursorFilters = errors.New("Cursor does not match the query filters")
+	// ErrCursorRequired is returned when a schema with a cursor key is given an after or before position instead of a cursor.
+	ErrCursorRequired = errors.New("Position must be given as a cursor")
+)
+
+// Cursor is a pagination cursor structure holding a keyset position.
+type Cursor struct {
+	Position  []string `json:"p"`
+	Backwards bool     `json:"b,omitempty"`
+	Filters   string   `json:"f"`
+	Issued    int64    `json:"t"`
+}
+
+// NewCursor creates a new cursor for a keyset position of a query issued now.
+func NewCursor(query url.Values, position []string, backwards bool) *Cursor {
+	return (*Paginator)(nil).newCursor(query, position, backwards)
+}
+
+// newCursor creates a new cursor for a keyset position of a query with the url parameters named by the paginator.
+func (paginator *Paginator) newCursor(query url.Values, position []string, backwards bool) *Cursor {
+	return &Cursor{
+		Position:  position,
+		Backwards: backwards,
+		Filters:   paginator.getFiltersHash(query),
+		Issued:    time.Now().Unix(),
+	}
+}
+
+// EncodeCursor encodes a cursor into an opaque token signed with the key.
+func EncodeCursor(cursor *Cursor, key []byte) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signCursor(encoded, key))
+}
+
+// DecodeCursor decodes a token signed with the key into a cursor.
+// Returns a cursor is malformed error if the token cannot be decoded or was not signed with the key.
+func DecodeCursor(token string, key []byte) (*Cursor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, ErrCursorMalformed
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signCursor(parts[0], key)) {
+		return nil, ErrCursorMalformed
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrCursorMalformed
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(payload, cursor); err != nil || len(cursor.Position) == 0 {
+		return nil, ErrCursorMalformed
+	}
+
+	return cursor, nil
+}
+
+// GetFiltersHash returns a hash of the url parameters that select and order the results of a query.
+func GetFiltersHash(query url.Values) string {
+	return (*Paginator)(nil).getFiltersHash(query)
+}
+
+// getFiltersHash returns a hash of the url parameters that select and order the results of a query.
+// The page, limit, after, before and cursor parameters do not invalidate a cursor when changed.
+func (paginator *Paginator) getFiltersHash(query url.Values) string {
+	filters := url.Values{}
+	for param, values := range query {
+		filters[param] = values
+	}
+
+	for _, param := range []string{"page", "limit", "after", "before", "cursor"} {
+		filters.Del(paginator.getParam(param))
+	}
+
+	hash := sha256.Sum256([]byte(filters.Encode()))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:16])
+}
+
+// GetCursor decodes and verifies the cursor url parameter of a query.
+// Returns nil if the query has no cursor.
+// Returns a cursor is malformed error if the cursor cannot be decoded or the schema has no cursor key.
+// Returns a cursor has expired error if the cursor is older than the schema cursor time to live.
+// Returns a cursor does not match the query filters error if the cursor was issued for different filters.
+func (schema *Schema) GetCursor(query url.Values) (*Cursor, error) {
+	return (*Paginator)(nil).getCursor(schema, query)
+}
+
+// getCursor decodes and verifies the cursor url parameter named by the paginator.
+func (paginator *Paginator) getCursor(schema *Schema, query url.Values) (*Cursor, error) {
+	cursorParam := paginator.getParam("cursor")
+	if len(query[cursorParam]) == 0 {
+		return nil, nil
+	}
+
+	if schema == nil || len(schema.CursorKey) == 0 {
+		return nil, ErrCursorMalformed
+	}
+
+	cursor, err := DecodeCursor(query.Get(cursorParam), schema.CursorKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.CursorTTL > 0 && time.Since(time.Unix(cursor.Issued, 0)) > schema.CursorTTL {
+		return nil, ErrCursorExpired
+	}
+
+	if cursor.Filters != paginator.getFiltersHash(query) {
+		return nil, ErrCursorFilters
+	}
+
+	return cursor, nil
+}
+
+// signCursor returns the signature of an encoded cursor payload.
+func signCursor(payload string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,243 @@
+package pagination_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yohgo/pagination"
+)
+
+// cursorSchema is a schema used to test cursors.
+var cursorSchema = &pagination.Schema{
+	Sorts:     map[string]string{"id": ""},
+	CursorKey: []byte("secret"),
+	CursorTTL: time.Hour,
+}
+
+// getCursorDataProvider provides data for the TestGetCursor function.
+var getCursorDataProvider = []struct {
+	name   string
+	query  string
+	cursor *pagination.Cursor
+	key    []byte
+	schema *pagination.Schema
+	want   []string
+	err    error
+}{
+	{
+		name:   "A cursor retrieval without a cursor",
+		query:  "order_by=id&limit=3",
+		schema: cursorSchema,
+		want:   nil,
+		err:    nil,
+	},
+	{
+		name:   "A successful cursor retrieval",
+		query:  "order_by=id&limit=3&name__equals=ammar",
+		cursor: &pagination.Cursor{Position: []string{"3"}},
+		key:    []byte("secret"),
+		schema: cursorSchema,
+		want:   []string{"3"},
+		err:    nil,
+	},
+	{
+		name:   "A cursor retrieval fails due to a cursor signed with another key",
+		query:  "order_by=id&limit=3",
+		cursor: &pagination.Cursor{Position: []string{"3"}},
+		key:    []byte("another secret"),
+		schema: cursorSchema,
+		want:   nil,
+		err:    pagination.ErrCursorMalformed,
+	},
+	{
+		name:   "A cursor retrieval fails due to a schema without a cursor key",
+		query:  "order_by=id&limit=3",
+		cursor: &pagination.Cursor{Position: []string{"3"}},
+		key:    []byte("secret"),
+		schema: nil,
+		want:   nil,
+		err:    pagination.ErrCursorMalformed,
+	},
+	{
+		name:   "A cursor retrieval fails due to an expired cursor",
+		query:  "order_by=id&limit=3",
+		cursor: &pagination.Cursor{Position: []string{"3"}, Issued: time.Now().Add(-2 * time.Hour).Unix()},
+		key:    []byte("secret"),
+		schema: cursorSchema,
+		want:   nil,
+		err:    pagination.ErrCursorExpired,
+	},
+}
+
+// TestGetCursor tests the paginator GetCursor method.
+func TestGetCursor(t *testing.T) {
+	t.Log("GetCursor")
+	// Check each test case
+	for _, testcase := range getCursorDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		if testcase.cursor != nil {
+			cursor := pagination.NewCursor(query, testcase.cursor.Position, testcase.cursor.Backwards)
+			if testcase.cursor.Issued != 0 {
+				cursor.Issued = testcase.cursor.Issued
+			}
+			query.Set("cursor", pagination.EncodeCursor(cursor, testcase.key))
+		}
+
+		got, err := testcase.schema.GetCursor(query)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check position
+		if got != nil && !reflect.DeepEqual(testcase.want, got.Position) {
+			t.Errorf("Expected position to be %v but got %v", testcase.want, got.Position)
+		}
+	}
+}
+
+// TestGetCursorFilters tests the paginator GetCursor method with changed filters.
+func TestGetCursorFilters(t *testing.T) {
+	t.Log("GetCursor with changed filters")
+
+	query, _ := url.ParseQuery("order_by=id&limit=3&name__equals=ammar")
+	token := pagination.EncodeCursor(pagination.NewCursor(query, []string{"3"}, false), cursorSchema.CursorKey)
+
+	changed, _ := url.ParseQuery("order_by=id&limit=3&name__equals=admin")
+	changed.Set("cursor", token)
+
+	if _, err := cursorSchema.GetCursor(changed); err != pagination.ErrCursorFilters {
+		t.Errorf("Expected error to be %q but got %q", pagination.ErrCursorFilters, err)
+	}
+}
+
+// TestNewQueryCursorPosition tests the paginator NewQuery method with a cursor position not matching the order by fields.
+func TestNewQueryCursorPosition(t *testing.T) {
+	t.Log("NewQuery with a cursor position not matching the order by fields")
+
+	query, _ := url.ParseQuery("order_by=id&limit=3")
+	token := pagination.EncodeCursor(pagination.NewCursor(query, []string{"3", "ammar"}, false), cursorSchema.CursorKey)
+	query.Set("cursor", token)
+
+	want := &pagination.ValidationError{Parameter: "cursor", Value: token, Err: pagination.ErrCursorMalformed}
+	got, err := pagination.NewQuery(query, cursorSchema)
+
+	// Check error
+	if !reflect.DeepEqual(want, err) {
+		t.Errorf("Expected error to be %v but got %v", want, err)
+	}
+
+	// Check query
+	if got != nil {
+		t.Errorf("Expected query to be %v but got %v", nil, got)
+	}
+}
+
+// TestNewQueryPositionWithCursorKey tests the paginator NewQuery method with a plain position and a schema cursor key.
+func TestNewQueryPositionWithCursorKey(t *testing.T) {
+	t.Log("NewQuery with a plain position and a schema cursor key")
+
+	// Check each position parameter
+	for _, param := range []string{"after", "before"} {
+		t.Log(param)
+
+		query := url.Values{"order_by": {"id"}, param: {"999"}}
+		want := &pagination.ValidationError{Parameter: param, Value: "999", Err: pagination.ErrCursorRequired}
+		got, err := pagination.NewQuery(query, cursorSchema)
+
+		// Check error
+		if !reflect.DeepEqual(want, err) {
+			t.Errorf("Expected error to be %v but got %v", want, err)
+		}
+
+		// Check query
+		if got != nil {
+			t.Errorf("Expected query to be %v but got %v", nil, got)
+		}
+	}
+}
+
+// decodeCursorDataProvider provides data for the TestDecodeCursor function.
+var decodeCursorDataProvider = []struct {
+	name  string
+	token string
+	err   error
+}{
+	{
+		name:  "A cursor decoding fails due to a missing signature",
+		token: "eyJwIjpbIjMiXX0",
+		err:   pagination.ErrCursorMalformed,
+	},
+	{
+		name:  "A cursor decoding fails due to an invalid signature encoding",
+		token: "eyJwIjpbIjMiXX0.!!!",
+		err:   pagination.ErrCursorMalformed,
+	},
+	{
+		name:  "A cursor decoding fails due to a tampered payload",
+		token: "eyJwIjpbIjQiXX0." + strings.Split(pagination.EncodeCursor(&pagination.Cursor{Position: []string{"3"}}, []byte("secret")), ".")[1],
+		err:   pagination.ErrCursorMalformed,
+	},
+	{
+		name:  "A cursor decoding fails due to a payload without a position",
+		token: pagination.EncodeCursor(&pagination.Cursor{}, []byte("secret")),
+		err:   pagination.ErrCursorMalformed,
+	},
+}
+
+// TestDecodeCursor tests the paginator DecodeCursor method.
+func TestDecodeCursor(t *testing.T) {
+	t.Log("DecodeCursor")
+	// Check each test case
+	for _, testcase := range decodeCursorDataProvider {
+		t.Log(testcase.name)
+
+		_, err := pagination.DecodeCursor(testcase.token, []byte("secret"))
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+	}
+
+	cursor := &pagination.Cursor{Position: []string{"3", "ammar"}, Backwards: true, Filters: "filters", Issued: 1}
+	got, err := pagination.DecodeCursor(pagination.EncodeCursor(cursor, []byte("secret")), []byte("secret"))
+
+	// Check round trip
+	if err != nil || !reflect.DeepEqual(cursor, got) {
+		t.Errorf("Expected cursor to be %v but got %v (%v)", cursor, got, err)
+	}
+}
+
+// TestCursorPagination tests paging forwards and backwards with cursor links.
+func TestCursorPagination(t *testing.T) {
+	t.Log("Cursor pagination")
+
+	first, _ := url.Parse("api.demo.com/v1/users?page=1&limit=2&order_by=id")
+	links := pagination.NewKeysetLinks(first, 2, []string{"1"}, []string{"2"}, cursorSchema)
+
+	// Check the next link carries a cursor
+	next, _ := url.Parse(links.Next)
+	if next.Query().Get("cursor") == "" || next.Query().Get("after") != "" || next.Query().Get("page") != "" {
+		t.Fatalf("Expected next link to carry a cursor but got %s", links.Next)
+	}
+
+	query, err := pagination.NewQuery(next.Query(), cursorSchema)
+	if err != nil || !reflect.DeepEqual([]string{"2"}, query.After) || query.Search.SQL != "((`id` > ?))" {
+		t.Fatalf("Expected query to be after 2 but got %v (%v)", query, err)
+	}
+
+	links = pagination.NewKeysetLinks(next, 2, []string{"3"}, []string{"4"}, cursorSchema)
+	previous, _ := url.Parse(links.Previous)
+
+	query, err = pagination.NewQuery(previous.Query(), cursorSchema)
+	if err != nil || !reflect.DeepEqual([]string{"3"}, query.Before) || query.GetOrder() != "`id` desc" {
+		t.Errorf("Expected query to be before 3 but got %v (%v)", query, err)
+	}
+}
@@ -0,0 +1,218 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect renders the database specific parts of the generated SQL.
+type Dialect interface {
+	// Placeholder returns the placeholder of the nth, starting at 1, parameter.
+	Placeholder(n int) string
+	// Quote quotes a single identifier.
+	Quote(identifier string) string
+	// DatePart returns an expression extracting the year, month or day part of a column.
+	DatePart(part, column string) string
+	// Like returns a condition matching a column against a pattern.
+	Like(column, pattern string) string
+	// ILike returns a condition matching a column against a pattern regardless of case.
+	ILike(column, pattern string) string
+	// EscapeLike escapes the wildcards of a value with the exclamation mark escape character of Like and ILike.
+	EscapeLike(value string) string
+}
+
+var (
+	// MySQL is the MySQL dialect, it is used when a schema has no dialect.
+	MySQL Dialect = mysqlDialect{}
+	// PostgreSQL is the PostgreSQL dialect.
+	PostgreSQL Dialect = postgresDialect{}
+	// SQLite is the SQLite dialect.
+	SQLite Dialect = sqliteDialect{}
+	// SQLServer is the Microsoft SQL Server dialect.
+	SQLServer Dialect = sqlserverDialect{}
+)
+
+// mysqlDialect is the MySQL dialect.
+type mysqlDialect struct{}
+
+// Placeholder returns a question mark placeholder.
+func (mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+// Quote quotes an identifier with backticks.
+func (mysqlDialect) Quote(identifier string) string {
+	return "`" + strings.Replace(identifier, "`", "``", -1) + "`"
+}
+
+// DatePart returns a YEAR, MONTH or DAY function call.
+func (mysqlDialect) DatePart(part, column string) string {
+	return strings.ToUpper(part) + "(" + column + ")"
+}
+
+// Like returns a LIKE condition escaped with an exclamation mark.
+func (mysqlDialect) Like(column, pattern string) string {
+	return column + " LIKE " + pattern + " ESCAPE '!'"
+}
+
+// ILike returns a LIKE condition on lower case values escaped with an exclamation mark.
+func (mysqlDialect) ILike(column, pattern string) string {
+	return "LOWER(" + column + ") LIKE LOWER(" + pattern + ") ESCAPE '!'"
+}
+
+// EscapeLike escapes the wildcards of a value.
+func (mysqlDialect) EscapeLike(value string) string {
+	return escapeLike(value, "!%_")
+}
+
+// postgresDialect is the PostgreSQL dialect.
+type postgresDialect struct{}
+
+// Placeholder returns a numbered dollar placeholder.
+func (postgresDialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// Quote quotes an identifier with double quotes.
+func (postgresDialect) Quote(identifier string) string {
+	return `"` + strings.Replace(identifier, `"`, `""`, -1) + `"`
+}
+
+// DatePart returns an EXTRACT expression.
+func (postgresDialect) DatePart(part, column string) string {
+	return "EXTRACT(" + strings.ToUpper(part) + " FROM " + column + ")"
+}
+
+// Like returns a LIKE condition escaped with an exclamation mark.
+func (postgresDialect) Like(column, pattern string) string {
+	return column + " LIKE " + pattern + " ESCAPE '!'"
+}
+
+// ILike returns an ILIKE condition escaped with an exclamation mark.
+func (postgresDialect) ILike(column, pattern string) string {
+	return column + " ILIKE " + pattern + " ESCAPE '!'"
+}
+
+// EscapeLike escapes the wildcards of a value.
+func (postgresDialect) EscapeLike(value string) string {
+	return escapeLike(value, "!%_")
+}
+
+// sqliteDialect is the SQLite dialect.
+type sqliteDialect struct{}
+
+// Placeholder returns a question mark placeholder.
+func (sqliteDialect) Placeholder(n int) string {
+	return "?"
+}
+
+// Quote quotes an identifier with double quotes.
+func (sqliteDialect) Quote(identifier string) string {
+	return `"` + strings.Replace(identifier, `"`, `""`, -1) + `"`
+}
+
+// DatePart returns a strftime expression converted to an integer.
+func (sqliteDialect) DatePart(part, column string) string {
+	format := map[string]string{"year": "%Y", "month": "%m", "day": "%d"}[part]
+
+	return "CAST(strftime('" + format + "', " + column + ") AS INTEGER)"
+}
+
+// Like returns a LIKE condition escaped with an exclamation mark.
+func (sqliteDialect) Like(column, pattern string) string {
+	return column + " LIKE " + pattern + " ESCAPE '!'"
+}
+
+// ILike returns a LIKE condition on lower case values escaped with an exclamation mark.
+func (sqliteDialect) ILike(column, pattern string) string {
+	return "LOWER(" + column + ") LIKE LOWER(" + pattern + ") ESCAPE '!'"
+}
+
+// EscapeLike escapes the wildcards of a value.
+func (sqliteDialect) EscapeLike(value string) string {
+	return escapeLike(value, "!%_")
+}
+
+// sqlserverDialect is the Microsoft SQL Server dialect.
+type sqlserverDialect struct{}
+
+// Placeholder returns a numbered at sign placeholder.
+func (sqlserverDialect) Placeholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+// Quote quotes an identifier with square brackets.
+func (sqlserverDialect) Quote(identifier string) string {
+	return "[" + strings.Replace(identifier, "]", "]]", -1) + "]"
+}
+
+// DatePart returns a DATEPART function call.
+func (sqlserverDialect) DatePart(part, column string) string {
+	return "DATEPART(" + part + ", " + column + ")"
+}
+
+// Like returns a LIKE condition escaped with an exclamation mark.
+func (sqlserverDialect) Like(column, pattern string) string {
+	return column + " LIKE " + pattern + " ESCAPE '!'"
+}
+
+// ILike returns a LIKE condition on lower case values escaped with an exclamation mark.
+func (sqlserverDialect) ILike(column, pattern string) string {
+	return "LOWER(" + column + ") LIKE LOWER(" + pattern + ") ESCAPE '!'"
+}
+
+// EscapeLike escapes the wildcards of a value, including character ranges.
+func (sqlserverDialect) EscapeLike(value string) string {
+	return escapeLike(value, "!%_[")
+}
+
+// escapeLike prefixes the special characters of a value, along with the exclamation mark, with an exclamation mark.
+func escapeLike(value, characters string) string {
+	var escaped []byte
+
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(characters, value[i]) >= 0 {
+			escaped = append(escaped, '!')
+		}
+		escaped = append(escaped, value[i])
+	}
+
+	return string(escaped)
+}
+
+// quoteColumn quotes a plain, optionally table qualified, column name.
+// Column expressions that are not plain column names are returned as they are.
+func quoteColumn(dialect Dialect, column string) string {
+	if !identifierPattern.MatchString(column) {
+		return column
+	}
+
+	parts := strings.Split(column, ".")
+	for i, part := range parts {
+		parts[i] = dialect.Quote(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// bindPlaceholders replaces the question mark placeholders of a condition with the dialect placeholders.
+// Question marks inside string literals are left as they are.
+func bindPlaceholders(dialect Dialect, condition string) string {
+	var bound []byte
+	inLiteral := false
+	n := 0
+
+	for i := 0; i < len(condition); i++ {
+		switch {
+		case condition[i] == '\'':
+			inLiteral = !inLiteral
+		case condition[i] == '?' && !inLiteral:
+			n++
+			bound = append(bound, dialect.Placeholder(n)...)
+			continue
+		}
+		bound = append(bound, condition[i])
+	}
+
+	return string(bound)
+}
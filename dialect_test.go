@@ -0,0 +1,204 @@
+package pagination_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// dialectDataProvider provides data for the TestDialect function.
+var dialectDataProvider = []struct {
+	name        string
+	dialect     pagination.Dialect
+	placeholder string
+	quote       string
+	datePart    string
+	like        string
+	ilike       string
+	escaped     string
+}{
+	{
+		name:        "The MySQL dialect",
+		dialect:     pagination.MySQL,
+		placeholder: "?",
+		quote:       "`first``name`",
+		datePart:    "YEAR(created_at)",
+		like:        "name LIKE ? ESCAPE '!'",
+		ilike:       "LOWER(name) LIKE LOWER(?) ESCAPE '!'",
+		escaped:     "50!%!_off[x]!!",
+	},
+	{
+		name:        "The PostgreSQL dialect",
+		dialect:     pagination.PostgreSQL,
+		placeholder: "$2",
+		quote:       "\"first`name\"",
+		datePart:    "EXTRACT(YEAR FROM created_at)",
+		like:        "name LIKE $2 ESCAPE '!'",
+		ilike:       "name ILIKE $2 ESCAPE '!'",
+		escaped:     "50!%!_off[x]!!",
+	},
+	{
+		name:        "The SQLite dialect",
+		dialect:     pagination.SQLite,
+		placeholder: "?",
+		quote:       "\"first`name\"",
+		datePart:    "CAST(strftime('%Y', created_at) AS INTEGER)",
+		like:        "name LIKE ? ESCAPE '!'",
+		ilike:       "LOWER(name) LIKE LOWER(?) ESCAPE '!'",
+		escaped:     "50!%!_off[x]!!",
+	},
+	{
+		name:        "The SQL Server dialect",
+		dialect:     pagination.SQLServer,
+		placeholder: "@p2",
+		quote:       "[first`name]",
+		datePart:    "DATEPART(year, created_at)",
+		like:        "name LIKE @p2 ESCAPE '!'",
+		ilike:       "LOWER(name) LIKE LOWER(@p2) ESCAPE '!'",
+		escaped:     "50!%!_off![x]!!",
+	},
+}
+
+// TestDialect tests the paginator dialects.
+func TestDialect(t *testing.T) {
+	t.Log("Dialect")
+	// Check each test case
+	for _, testcase := range dialectDataProvider {
+		t.Log(testcase.name)
+
+		placeholder := testcase.dialect.Placeholder(2)
+
+		// Check placeholder
+		if testcase.placeholder != placeholder {
+			t.Errorf("Expected placeholder to be %s but got %s", testcase.placeholder, placeholder)
+		}
+
+		// Check quote
+		if quote := testcase.dialect.Quote("first`name"); testcase.quote != quote {
+			t.Errorf("Expected quote to be %s but got %s", testcase.quote, quote)
+		}
+
+		// Check date part
+		if datePart := testcase.dialect.DatePart("year", "created_at"); testcase.datePart != datePart {
+			t.Errorf("Expected date part to be %s but got %s", testcase.datePart, datePart)
+		}
+
+		// Check like
+		if like := testcase.dialect.Like("name", placeholder); testcase.like != like {
+			t.Errorf("Expected like to be %s but got %s", testcase.like, like)
+		}
+
+		// Check escaped like value
+		if escaped := testcase.dialect.EscapeLike("50%_off[x]!"); testcase.escaped != escaped {
+			t.Errorf("Expected escaped value to be %s but got %s", testcase.escaped, escaped)
+		}
+
+		// Check case insensitive like
+		if ilike := testcase.dialect.ILike("name", placeholder); testcase.ilike != ilike {
+			t.Errorf("Expected case insensitive like to be %s but got %s", testcase.ilike, ilike)
+		}
+	}
+}
+
+// dialectSearchDataProvider provides data for the TestDialectSearch function.
+var dialectSearchDataProvider = []struct {
+	name    string
+	query   string
+	dialect pagination.Dialect
+	search  *pagination.Search
+	order   string
+}{
+	{
+		name:    "A search and order with the default dialect",
+		query:   "created__year=2017&order_by=name",
+		dialect: nil,
+		search: &pagination.Search{
+			SQL:        "((YEAR(`created`) = ?))",
+			Parameters: []interface{}{"2017"},
+		},
+		order: "`users`.`name` asc",
+	},
+	{
+		name:    "A search and order with the PostgreSQL dialect",
+		query:   "created__year=2017&order_by=name&after=dave",
+		dialect: pagination.PostgreSQL,
+		search: &pagination.Search{
+			SQL:        `(((EXTRACT(YEAR FROM "created") = $1)) AND (("users"."name" > $2)))`,
+			Parameters: []interface{}{"2017", "dave"},
+		},
+		order: `"users"."name" asc`,
+	},
+	{
+		name:    "A search of a reserved word column with the PostgreSQL dialect",
+		query:   "order__equals=1&order_by=name",
+		dialect: pagination.PostgreSQL,
+		search: &pagination.Search{
+			SQL:        `(("order" = $1))`,
+			Parameters: []interface{}{"1"},
+		},
+		order: `"users"."name" asc`,
+	},
+	{
+		name:    "A case insensitive search with the PostgreSQL dialect",
+		query:   "name__icontains=DAV&order_by=name",
+		dialect: pagination.PostgreSQL,
+		search: &pagination.Search{
+			SQL:        "((COALESCE(name, '?') ILIKE $1 ESCAPE '!'))",
+			Parameters: []interface{}{"%DAV%"},
+		},
+		order: `"users"."name" asc`,
+	},
+	{
+		name:    "A case insensitive search with the SQLite dialect",
+		query:   "name__istartswith=DAV&order_by=name",
+		dialect: pagination.SQLite,
+		search: &pagination.Search{
+			SQL:        "((LOWER(COALESCE(name, '?')) LIKE LOWER(?) ESCAPE '!'))",
+			Parameters: []interface{}{"DAV%"},
+		},
+		order: `"users"."name" asc`,
+	},
+	{
+		name:    "A search and order with the SQL Server dialect",
+		query:   "name__contains=dav&order_by=name&after=dave",
+		dialect: pagination.SQLServer,
+		search: &pagination.Search{
+			SQL:        "(((COALESCE(name, '?') LIKE @p1 ESCAPE '!')) AND (([users].[name] > @p2)))",
+			Parameters: []interface{}{"%dav%", "dave"},
+		},
+		order: "[users].[name] asc",
+	},
+}
+
+// TestDialectSearch tests the paginator NewQuery method with dialects.
+func TestDialectSearch(t *testing.T) {
+	t.Log("NewQuery with dialects")
+	// Check each test case
+	for _, testcase := range dialectSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewQuery(query, &pagination.Schema{
+			Fields:  map[string]pagination.Field{"name": {Column: "COALESCE(name, '?')"}, "created": {}, "order": {}},
+			Sorts:   map[string]string{"name": "users.name"},
+			Dialect: testcase.dialect,
+		})
+
+		// Check error
+		if err != nil {
+			t.Fatalf("Expected error to be nil but got %q", err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.search, got.Search) {
+			t.Errorf("Expected search to be %v but got %v", testcase.search, got.Search)
+		}
+
+		// Check order
+		if order := got.GetOrder(); testcase.order != order {
+			t.Errorf("Expected order to be %s but got %s", testcase.order, order)
+		}
+	}
+}
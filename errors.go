@@ -0,0 +1,144 @@
+package pagination
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidPage is returned when the page is less than 1 or not an integer.
+	ErrInvalidPage = errors.New("Page is invalid")
+	// ErrMissingPage is returned when a limit is requested without a page.
+	ErrMissingPage = errors.New("Page is missing")
+	// ErrPageWithPosition is returned when a page is combined with an after or before position.
+	ErrPageWithPosition = errors.New("Page cannot be combined with after or before")
+	// ErrAfterWithBefore is returned when an after position is combined with a before position.
+	ErrAfterWithBefore = errors.New("After cannot be combined with before")
+	// ErrCursorWithPosition is returned when a cursor is combined with a page, after or before position.
+	ErrCursorWithPosition = errors.New("Cursor cannot be combined with page, after or before")
+	// ErrInvalidLimit is returned when the limit is less than 1 or not an integer.
+	ErrInvalidLimit = errors.New("Limit is invalid")
+	// ErrLimitTooLarge is returned when the limit is above the maximum limit of a paginator rejecting overflows.
+	ErrLimitTooLarge = errors.New("Limit is too large")
+	// ErrInvalidOrderBy is returned when an order by field cannot be sorted.
+	ErrInvalidOrderBy = errors.New("Order by is invalid")
+	// ErrMissingOrderBy is returned when an order is requested without an order by.
+	ErrMissingOrderBy = errors.New("Order by is missing")
+	// ErrInvalidOrder is returned when the order is neither "asc" nor "desc".
+	ErrInvalidOrder = errors.New("Order is invalid")
+	// ErrInvalidAfter is returned when an after position does not have a value per sort term.
+	ErrInvalidAfter = errors.New("After is invalid")
+	// ErrInvalidBefore is returned when a before position does not have a value per sort term.
+	ErrInvalidBefore = errors.New("Before is invalid")
+	// ErrInvalidSearchOperator is returned when the search operator is neither "AND" nor "OR".
+	ErrInvalidSearchOperator = errors.New("Search operator is invalid")
+	// ErrMissingSearchOperator is returned when multiple search conditions are provided without a search operator.
+	ErrMissingSearchOperator = errors.New("Search operator is missing")
+	// ErrMissingSearchConditions is returned when a search operator is provided without at least two search conditions.
+	ErrMissingSearchConditions = errors.New("Cannot find search conditions")
+	// ErrUnknownField is returned when a search condition refers to a field that cannot be searched.
+	ErrUnknownField = errors.New("Unknown search field")
+	// ErrUnknownOperation is returned when a search condition refers to an unknown search operation.
+	ErrUnknownOperation = errors.New("Unknown search operation")
+	// ErrTooManyValues is returned when a search condition has more values than the schema maximum list length.
+	ErrTooManyValues = errors.New("Too many values")
+	// ErrInvalidValue is returned when a search value is not valid for its field or search operation.
+	ErrInvalidValue = errors.New("Value is invalid")
+	// ErrInvalidRange is returned when a Range header is not a single items range or is combined with a page or keyset position.
+	ErrInvalidRange = errors.New("Range is invalid")
+	// ErrInvalidFilter is returned when a filter expression cannot be parsed.
+	ErrInvalidFilter = errors.New("Filter is invalid")
+)
+
+// ValidationError is a pagination validation error structure describing an invalid url parameter.
+// The error wraps one of the package sentinel errors, which can be checked with errors.Is.
+type ValidationError struct {
+	Parameter string
+	Value     string
+	Err       error
+	Message   string
+}
+
+// Error returns the message of a validation error, or the message of its sentinel error when it has none.
+func (err *ValidationError) Error() string {
+	if err.Message != "" {
+		return err.Message
+	}
+
+	return err.Err.Error()
+}
+
+// Unwrap returns the sentinel error of a validation error.
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// ValidationErrors is a pagination aggregate error holding every validation error of a query.
+// It is returned instead of the first validation error when the schema collects errors.
+type ValidationErrors []*ValidationError
+
+// Error returns the messages of the validation errors separated by semicolons.
+func (errs ValidationErrors) Error() string {
+	var messages []string
+
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether any of the validation errors matches the target error.
+func (errs ValidationErrors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first validation error that matches the target and sets the target to it.
+func (errs ValidationErrors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add appends an error to the validation errors.
+func (errs *ValidationErrors) add(err error, parameter, value string) {
+	if list, ok := err.(ValidationErrors); ok {
+		*errs = append(*errs, list...)
+	} else if err != nil {
+		*errs = append(*errs, toValidationError(err, parameter, value))
+	}
+}
+
+// get returns the validation errors when the schema collects errors, or the first validation error otherwise.
+// Returns nil if there are no validation errors.
+func (errs ValidationErrors) get(schema *Schema) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if schema != nil && schema.CollectErrors {
+		return errs
+	}
+
+	return errs[0]
+}
+
+// toValidationError returns an error as a validation error.
+// Returns an invalid value error of the parameter for an error that is not a validation error.
+func toValidationError(err error, parameter, value string) *ValidationError {
+	if validationErr, ok := err.(*ValidationError); ok {
+		return validationErr
+	}
+
+	return &ValidationError{Parameter: parameter, Value: value, Err: ErrInvalidValue, Message: err.Error()}
+}
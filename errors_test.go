@@ -0,0 +1,103 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// collectingSchema is a schema collecting every validation error.
+var collectingSchema = &pagination.Schema{
+	Fields:        map[string]pagination.Field{"age": {Type: pagination.TypeInt}},
+	Sorts:         map[string]string{"age": ""},
+	CollectErrors: true,
+}
+
+// validationErrorsDataProvider provides data for the TestValidationErrors function.
+var validationErrorsDataProvider = []struct {
+	name   string
+	query  string
+	schema *pagination.Schema
+	err    error
+}{
+	{
+		name:   "A failed query creation - first validation error",
+		query:  "page=0&limit=0&age__equals=abc",
+		schema: nil,
+		err:    &pagination.ValidationError{Parameter: "page", Value: "0", Err: pagination.ErrInvalidPage},
+	},
+	{
+		name:   "A failed query creation - all validation errors",
+		query:  "page=0&limit=0&order_by=name&age__equals=abc&name__equals=ammar",
+		schema: collectingSchema,
+		err: pagination.ValidationErrors{
+			{Parameter: "page", Value: "0", Err: pagination.ErrInvalidPage},
+			{Parameter: "limit", Value: "0", Err: pagination.ErrInvalidLimit},
+			{Parameter: "order_by", Value: "name", Err: pagination.ErrInvalidOrderBy},
+			{Parameter: "age__equals", Value: "abc", Err: pagination.ErrInvalidValue, Message: "Value 'abc' of 'age' is not a valid int"},
+			{Parameter: "name__equals", Value: "ammar", Err: pagination.ErrUnknownField, Message: "Unknown search field 'name'"},
+		},
+	},
+	{
+		name:   "A failed query creation - collected order validation errors",
+		query:  "page=1&limit=5&order=up",
+		schema: collectingSchema,
+		err: pagination.ValidationErrors{
+			{Parameter: "order_by", Err: pagination.ErrMissingOrderBy},
+			{Parameter: "order", Value: "up", Err: pagination.ErrInvalidOrder},
+		},
+	},
+	{
+		name:   "Successful query creation - no validation errors",
+		query:  "page=1&limit=5&order_by=age&age__equals=18",
+		schema: collectingSchema,
+		err:    nil,
+	},
+}
+
+// TestValidationErrors tests the paginator NewQuery method validation errors.
+func TestValidationErrors(t *testing.T) {
+	t.Log("NewQuery validation errors")
+
+	// Check each test case
+	for _, testcase := range validationErrorsDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		_, err := pagination.NewQuery(query, testcase.schema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+	}
+}
+
+// TestValidationErrorsUnwrap tests the paginator validation errors with errors.Is and errors.As.
+func TestValidationErrorsUnwrap(t *testing.T) {
+	t.Log("Validation errors with errors.Is and errors.As")
+
+	query, _ := url.ParseQuery("page=0&age__equals=abc")
+	_, err := pagination.NewQuery(query, collectingSchema)
+
+	if err.Error() != "Page is invalid; Value 'abc' of 'age' is not a valid int" {
+		t.Errorf("Expected error message to be %q but got %q", "Page is invalid; Value 'abc' of 'age' is not a valid int", err.Error())
+	}
+
+	if !errors.Is(err, pagination.ErrInvalidValue) || errors.Is(err, pagination.ErrInvalidLimit) {
+		t.Errorf("Expected error to be an invalid value error but got %v", err)
+	}
+
+	var validationErr *pagination.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Parameter != "page" {
+		t.Errorf("Expected error to be a validation error of page but got %v", validationErr)
+	}
+
+	_, err = pagination.NewQuery(url.Values{"limit": {"abc"}, "page": {"1"}})
+	if !errors.Is(err, pagination.ErrInvalidLimit) || err.Error() != "Limit is invalid" {
+		t.Errorf("Expected error to be a limit is invalid error but got %v", err)
+	}
+}
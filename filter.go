@@ -0,0 +1,275 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// maxFilterDepth is the maximum nesting depth of a filter expression.
+const maxFilterDepth = 32
+
+// Filter is a pagination filter expression node.
+// A node is either an AND, OR or NOT operation on its operands or, when it has no operator, a search condition.
+type Filter struct {
+	Operator  string
+	Operands  []*Filter
+	Parameter string
+	Value     string
+}
+
+// ParseFilter parses a filter expression such as `status__equals=active AND (age__greaterthan=18 OR vip__equals=true)`.
+// Conditions have the same format as search parameters, and values containing spaces or parentheses are double quoted.
+// NOT takes precedence over AND, which takes precedence over OR.
+// Returns a filter is invalid error of the filter parameter if the expression cannot be parsed.
+func ParseFilter(expression string) (*Filter, error) {
+	filter, err := parseFilter(expression, "__")
+	if err != nil {
+		return nil, &ValidationError{Parameter: "filter", Value: expression, Err: ErrInvalidFilter, Message: err.Error()}
+	}
+
+	return filter, nil
+}
+
+// parseFilter parses a filter expression with conditions separated by a search separator.
+func parseFilter(expression, separator string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens, separator: separator}
+	filter, err := parser.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.position != len(tokens) {
+		return nil, errors.New("Filter is invalid near '" + tokens[parser.position] + "'")
+	}
+
+	return filter, nil
+}
+
+// getFilterSearch returns the search of the filter url parameter with question mark placeholders.
+// Returns nil if the query has no filter.
+func (paginator *Paginator) getFilterSearch(query url.Values, schema *Schema) (*Search, error) {
+	filterParam := paginator.getParam("filter")
+	if query.Get(filterParam) == "" {
+		return nil, nil
+	}
+
+	filter, err := parseFilter(query.Get(filterParam), paginator.getSeparator())
+	if err != nil {
+		return nil, &ValidationError{Parameter: filterParam, Value: query.Get(filterParam), Err: ErrInvalidFilter, Message: err.Error()}
+	}
+
+	search := &Search{}
+	if search.SQL, err = filter.compile(paginator, schema, &search.Parameters); err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
+
+// compile returns the condition of a filter and appends its parameters.
+func (filter *Filter) compile(paginator *Paginator, schema *Schema, parameters *[]interface{}) (string, error) {
+	if filter.Operator == "" {
+		condition, conditionParameters, err := paginator.getCondition(schema, filter.Parameter, []string{filter.Value})
+		if err != nil {
+			return "", err
+		}
+
+		*parameters = append(*parameters, conditionParameters...)
+
+		return condition, nil
+	}
+
+	var conditions []string
+
+	for _, operand := range filter.Operands {
+		condition, err := operand.compile(paginator, schema, parameters)
+		if err != nil {
+			return "", err
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if filter.Operator == "NOT" {
+		return "(NOT " + conditions[0] + ")", nil
+	}
+
+	return "(" + strings.Join(conditions, " "+filter.Operator+" ") + ")", nil
+}
+
+// filterParser is a recursive descent parser of filter expression tokens.
+type filterParser struct {
+	tokens    []string
+	position  int
+	separator string
+}
+
+// parseOr parses operands separated by OR.
+func (parser *filterParser) parseOr(depth int) (*Filter, error) {
+	return parser.parseOperation("OR", depth, parser.parseAnd)
+}
+
+// parseAnd parses operands separated by AND.
+func (parser *filterParser) parseAnd(depth int) (*Filter, error) {
+	return parser.parseOperation("AND", depth, parser.parseNot)
+}
+
+// parseOperation parses operands separated by an operator.
+func (parser *filterParser) parseOperation(operator string, depth int, parseOperand func(int) (*Filter, error)) (*Filter, error) {
+	operand, err := parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*Filter{operand}
+
+	for parser.peek() == operator {
+		parser.position++
+		if operand, err = parseOperand(depth); err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return operand, nil
+	}
+
+	return &Filter{Operator: operator, Operands: operands}, nil
+}
+
+// parseNot parses an operand optionally negated by NOT.
+func (parser *filterParser) parseNot(depth int) (*Filter, error) {
+	if parser.peek() != "NOT" {
+		return parser.parsePrimary(depth)
+	}
+
+	parser.position++
+	if depth >= maxFilterDepth {
+		return nil, errors.New("Filter is too deeply nested")
+	}
+
+	operand, err := parser.parseNot(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filter{Operator: "NOT", Operands: []*Filter{operand}}, nil
+}
+
+// parsePrimary parses a parenthesized expression or a search condition.
+func (parser *filterParser) parsePrimary(depth int) (*Filter, error) {
+	if parser.position == len(parser.tokens) {
+		return nil, errors.New("Filter is incomplete")
+	}
+
+	token := parser.tokens[parser.position]
+	parser.position++
+
+	if token == "(" {
+		if depth >= maxFilterDepth {
+			return nil, errors.New("Filter is too deeply nested")
+		}
+
+		filter, err := parser.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if parser.peek() != ")" {
+			return nil, errors.New("Filter is missing a closing parenthesis")
+		}
+		parser.position++
+
+		return filter, nil
+	}
+
+	equals := strings.Index(token, "=")
+	if equals < 0 || !strings.Contains(token[:equals], parser.separator) {
+		return nil, errors.New("Filter is invalid near '" + token + "'")
+	}
+
+	value, err := unquoteFilterValue(token[equals+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filter{Parameter: token[:equals], Value: value}, nil
+}
+
+// peek returns the current token, with AND, OR and NOT in upper case.
+func (parser *filterParser) peek() string {
+	if parser.position == len(parser.tokens) {
+		return ""
+	}
+
+	token := parser.tokens[parser.position]
+	if upper := strings.ToUpper(token); upper == "AND" || upper == "OR" || upper == "NOT" {
+		return upper
+	}
+
+	return token
+}
+
+// tokenizeFilter splits a filter expression into parentheses, operators and conditions.
+// Returns a filter is invalid error if a quoted value is not terminated.
+func tokenizeFilter(expression string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(expression); {
+		switch character := expression[i]; {
+		case character == ' ' || character == '\t' || character == '\n' || character == '\r':
+			i++
+		case character == '(' || character == ')':
+			tokens = append(tokens, string(character))
+			i++
+		default:
+			start := i
+			for i < len(expression) && !strings.ContainsRune(" \t\n\r()", rune(expression[i])) {
+				if expression[i] == '"' {
+					// Skips the quoted value along with its escaped characters
+					for i++; i < len(expression) && expression[i] != '"'; i++ {
+						if expression[i] == '\\' {
+							i++
+						}
+					}
+
+					if i >= len(expression) {
+						return nil, errors.New("Filter has an unterminated quoted value")
+					}
+				}
+				i++
+			}
+			tokens = append(tokens, expression[start:i])
+		}
+	}
+
+	return tokens, nil
+}
+
+// unquoteFilterValue removes the double quotes and backslash escapes of a quoted filter value.
+func unquoteFilterValue(value string) (string, error) {
+	if !strings.HasPrefix(value, `"`) {
+		return value, nil
+	}
+
+	if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+		return "", errors.New("Filter is invalid near '" + value + "'")
+	}
+
+	var unquoted []byte
+	for i := 1; i < len(value)-1; i++ {
+		if value[i] == '\\' {
+			i++
+		}
+		unquoted = append(unquoted, value[i])
+	}
+
+	return string(unquoted), nil
+}
@@ -0,0 +1,188 @@
+package pagination_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// parseFilterDataProvider provides data for the TestParseFilter function.
+var parseFilterDataProvider = []struct {
+	name       string
+	expression string
+	want       *pagination.Filter
+	err        error
+}{
+	{
+		name:       "A successful filter parsing with a single condition",
+		expression: "status__equals=active",
+		want:       &pagination.Filter{Parameter: "status__equals", Value: "active"},
+		err:        nil,
+	},
+	{
+		name:       "A successful filter parsing with grouping",
+		expression: "status__equals=active AND (age__greaterthan=18 or vip__equals=true)",
+		want: &pagination.Filter{
+			Operator: "AND",
+			Operands: []*pagination.Filter{
+				{Parameter: "status__equals", Value: "active"},
+				{
+					Operator: "OR",
+					Operands: []*pagination.Filter{
+						{Parameter: "age__greaterthan", Value: "18"},
+						{Parameter: "vip__equals", Value: "true"},
+					},
+				},
+			},
+		},
+		err: nil,
+	},
+	{
+		name:       "A successful filter parsing with precedence",
+		expression: "a__equals=1 OR NOT b__equals=2 AND c__equals=3",
+		want: &pagination.Filter{
+			Operator: "OR",
+			Operands: []*pagination.Filter{
+				{Parameter: "a__equals", Value: "1"},
+				{
+					Operator: "AND",
+					Operands: []*pagination.Filter{
+						{Operator: "NOT", Operands: []*pagination.Filter{{Parameter: "b__equals", Value: "2"}}},
+						{Parameter: "c__equals", Value: "3"},
+					},
+				},
+			},
+		},
+		err: nil,
+	},
+	{
+		name:       "A successful filter parsing with a quoted value",
+		expression: `name__contains="john \"(jj)\" doe"`,
+		want:       &pagination.Filter{Parameter: "name__contains", Value: `john "(jj)" doe`},
+		err:        nil,
+	},
+	{
+		name:       "A failed filter parsing due to a missing closing parenthesis",
+		expression: "(a__equals=1 OR b__equals=2",
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: "(a__equals=1 OR b__equals=2", Err: pagination.ErrInvalidFilter, Message: "Filter is missing a closing parenthesis"},
+	},
+	{
+		name:       "A failed filter parsing due to an unexpected closing parenthesis",
+		expression: "a__equals=1)",
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: "a__equals=1)", Err: pagination.ErrInvalidFilter, Message: "Filter is invalid near ')'"},
+	},
+	{
+		name:       "A failed filter parsing due to a missing operand",
+		expression: "a__equals=1 AND",
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: "a__equals=1 AND", Err: pagination.ErrInvalidFilter, Message: "Filter is incomplete"},
+	},
+	{
+		name:       "A failed filter parsing due to an invalid condition",
+		expression: "a__equals=1 AND b",
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: "a__equals=1 AND b", Err: pagination.ErrInvalidFilter, Message: "Filter is invalid near 'b'"},
+	},
+	{
+		name:       "A failed filter parsing due to an unterminated quoted value",
+		expression: `name__equals="john`,
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: `name__equals="john`, Err: pagination.ErrInvalidFilter, Message: "Filter has an unterminated quoted value"},
+	},
+	{
+		name:       "A failed filter parsing due to deep nesting",
+		expression: strings.Repeat("(", 40) + "a__equals=1" + strings.Repeat(")", 40),
+		want:       nil,
+		err:        &pagination.ValidationError{Parameter: "filter", Value: strings.Repeat("(", 40) + "a__equals=1" + strings.Repeat(")", 40), Err: pagination.ErrInvalidFilter, Message: "Filter is too deeply nested"},
+	},
+}
+
+// TestParseFilter tests the paginator ParseFilter method.
+func TestParseFilter(t *testing.T) {
+	t.Log("ParseFilter")
+	// Check each test case
+	for _, testcase := range parseFilterDataProvider {
+		t.Log(testcase.name)
+
+		got, err := pagination.ParseFilter(testcase.expression)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check filter
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected filter to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// filterSearchDataProvider provides data for the TestFilterSearch function.
+var filterSearchDataProvider = []struct {
+	name  string
+	query url.Values
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation with a filter",
+		query: url.Values{"filter": {"status__equals=active AND (age__greaterthan=18 OR NOT vip__equals=false)"}},
+		want: &pagination.Search{
+			SQL:        "((`status` = ?) AND ((`age` > ?) OR (NOT (`vip` = ?))))",
+			Parameters: []interface{}{"active", "18", "false"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation with a filter and a search condition",
+		query: url.Values{"filter": {"age__greaterthan=18 OR vip__equals=true"}, "status__equals": {"active"}},
+		want: &pagination.Search{
+			SQL:        "(((`status` = ?)) AND ((`age` > ?) OR (`vip` = ?)))",
+			Parameters: []interface{}{"active", "18", "true"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation with a filter on an unknown field",
+		query: url.Values{"filter": {"status__equals=active OR password__equals=secret"}},
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "password__equals", Value: "secret", Err: pagination.ErrUnknownField, Message: "Unknown search field 'password'"},
+	},
+	{
+		name:  "A failed search creation with a filter having an unknown operation",
+		query: url.Values{"filter": {"status__is=active"}},
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "status__is", Value: "active", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'is'"},
+	},
+}
+
+// TestFilterSearch tests the paginator NewSearch method with filters.
+func TestFilterSearch(t *testing.T) {
+	t.Log("NewSearch with filters")
+
+	schema := &pagination.Schema{
+		Fields: map[string]pagination.Field{"status": {}, "age": {}, "vip": {}},
+	}
+	// Check each test case
+	for _, testcase := range filterSearchDataProvider {
+		t.Log(testcase.name)
+
+		got, err := pagination.NewSearch(testcase.query, schema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
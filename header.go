@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrLinkHeaderMalformed is returned when a Link header value cannot be parsed.
+var ErrLinkHeaderMalformed = errors.New("Link header is malformed")
+
+// linkRelations are the link relation types of the pagination links, in their Link header order.
+var linkRelations = []string{"next", "prev", "first", "last", "self"}
+
+// GetLinkHeader returns the links as an RFC 8288 Link header value such as `<api.demo.com/v1/users?page=2>; rel="next"`.
+// Returns the next, prev, first, last and self links in this order, without the empty links.
+// Returns an empty header value when there are no links.
+func (links *Links) GetLinkHeader() string {
+	if links == nil {
+		return ""
+	}
+
+	var values []string
+
+	for _, relation := range linkRelations {
+		if target := *links.getLink(relation); target != "" {
+			values = append(values, "<"+target+`>; rel="`+relation+`"`)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// ParseLinkHeader parses an RFC 8288 Link header value into pagination links.
+// A link with several relation types sets each of the matching links, and other relation types are ignored.
+// Returns a link header is malformed error if the value cannot be parsed.
+func ParseLinkHeader(header string) (*Links, error) {
+	links := &Links{}
+	parser := &linkHeaderParser{header: header}
+
+	for parser.skip(", \t"); !parser.isDone(); parser.skip(", \t") {
+		target, relation, err := parser.parseLink()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, relation := range strings.Fields(strings.ToLower(relation)) {
+			if link := links.getLink(relation); link != nil {
+				*link = target
+			}
+		}
+	}
+
+	return links, nil
+}
+
+// getLink returns the link of a link relation type, or nil for a type that is not a pagination link.
+func (links *Links) getLink(relation string) *string {
+	switch relation {
+	case "next":
+		return &links.Next
+	case "prev", "previous":
+		return &links.Previous
+	case "first":
+		return &links.First
+	case "last":
+		return &links.Last
+	case "self":
+		return &links.Self
+	}
+
+	return nil
+}
+
+// linkHeaderParser is a parser of the link values of a Link header value.
+type linkHeaderParser struct {
+	header   string
+	position int
+}
+
+// parseLink parses a link value made of a target between angle brackets followed by its parameters.
+// Returns the target and the value of the first rel parameter.
+func (parser *linkHeaderParser) parseLink() (target, relation string, err error) {
+	if parser.header[parser.position] != '<' {
+		return "", "", ErrLinkHeaderMalformed
+	}
+
+	end := strings.IndexByte(parser.header[parser.position:], '>')
+	if end < 0 {
+		return "", "", ErrLinkHeaderMalformed
+	}
+
+	target = parser.header[parser.position+1 : parser.position+end]
+	parser.position += end + 1
+	hasRelation := false
+
+	for parser.skip(" \t"); !parser.isDone() && parser.header[parser.position] != ','; parser.skip(" \t") {
+		if parser.header[parser.position] != ';' {
+			return "", "", ErrLinkHeaderMalformed
+		}
+		parser.position++
+		parser.skip(" \t")
+
+		name := strings.ToLower(strings.TrimSpace(parser.readUntil("=;,")))
+		value := ""
+
+		if !parser.isDone() && parser.header[parser.position] == '=' {
+			parser.position++
+			parser.skip(" \t")
+			if value, err = parser.parseValue(); err != nil {
+				return "", "", err
+			}
+		}
+
+		// Only the first rel parameter of a link is used
+		if name == "rel" && !hasRelation {
+			relation, hasRelation = value, true
+		}
+	}
+
+	return target, relation, nil
+}
+
+// parseValue parses a token or a quoted string parameter value.
+func (parser *linkHeaderParser) parseValue() (string, error) {
+	if parser.isDone() || parser.header[parser.position] != '"' {
+		return strings.TrimSpace(parser.readUntil(";,")), nil
+	}
+
+	var value []byte
+	for parser.position++; parser.position < len(parser.header); parser.position++ {
+		switch character := parser.header[parser.position]; {
+		case character == '\\' && parser.position+1 < len(parser.header):
+			parser.position++
+			value = append(value, parser.header[parser.position])
+		case character == '"':
+			parser.position++
+			return string(value), nil
+		default:
+			value = append(value, character)
+		}
+	}
+
+	return "", ErrLinkHeaderMalformed
+}
+
+// readUntil returns the characters up to any of the stop characters, or up to the end of the header.
+func (parser *linkHeaderParser) readUntil(stop string) string {
+	start := parser.position
+	for !parser.isDone() && !strings.ContainsRune(stop, rune(parser.header[parser.position])) {
+		parser.position++
+	}
+
+	return parser.header[start:parser.position]
+}
+
+// skip skips any of the characters.
+func (parser *linkHeaderParser) skip(characters string) {
+	for !parser.isDone() && strings.ContainsRune(characters, rune(parser.header[parser.position])) {
+		parser.position++
+	}
+}
+
+// isDone reports whether the whole header was parsed.
+func (parser *linkHeaderParser) isDone() bool {
+	return parser.position >= len(parser.header)
+}
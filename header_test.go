@@ -0,0 +1,150 @@
+package pagination_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// getLinkHeaderDataProvider provides data for the TestGetLinkHeader function.
+var getLinkHeaderDataProvider = []struct {
+	name  string
+	links *pagination.Links
+	want  string
+}{
+	{
+		name:  "A link header retrieval with no links",
+		links: &pagination.Links{},
+		want:  "",
+	},
+	{
+		name: "A link header retrieval with next, previous and self links",
+		links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?limit=3&page=3",
+			Previous: "api.demo.com/v1/users?limit=3&page=1",
+			Self:     "api.demo.com/v1/users?page=2&limit=3",
+		},
+		want: `<api.demo.com/v1/users?limit=3&page=3>; rel="next", <api.demo.com/v1/users?limit=3&page=1>; rel="prev", <api.demo.com/v1/users?page=2&limit=3>; rel="self"`,
+	},
+	{
+		name: "A link header retrieval with all links",
+		links: &pagination.Links{
+			Next:     "/users?page=3",
+			Previous: "/users?page=1",
+			Self:     "/users?page=2",
+			First:    "/users?page=1",
+			Last:     "/users?page=5",
+		},
+		want: `</users?page=3>; rel="next", </users?page=1>; rel="prev", </users?page=1>; rel="first", </users?page=5>; rel="last", </users?page=2>; rel="self"`,
+	},
+}
+
+// TestGetLinkHeader tests the paginator GetLinkHeader method.
+func TestGetLinkHeader(t *testing.T) {
+	t.Log("GetLinkHeader")
+
+	// Check each test case
+	for _, testcase := range getLinkHeaderDataProvider {
+		t.Log(testcase.name)
+
+		got := testcase.links.GetLinkHeader()
+
+		// Check header
+		if testcase.want != got {
+			t.Errorf("Expected header to be %q but got %q", testcase.want, got)
+		}
+	}
+}
+
+// parseLinkHeaderDataProvider provides data for the TestParseLinkHeader function.
+var parseLinkHeaderDataProvider = []struct {
+	name   string
+	header string
+	want   *pagination.Links
+	err    error
+}{
+	{
+		name:   "Successful link header parsing - empty header",
+		header: "",
+		want:   &pagination.Links{},
+		err:    nil,
+	},
+	{
+		name:   "Successful link header parsing - rendered header",
+		header: `</users?page=3>; rel="next", </users?page=1>; rel="prev", </users?page=1>; rel="first", </users?page=5>; rel="last", </users?page=2>; rel="self"`,
+		want: &pagination.Links{
+			Next:     "/users?page=3",
+			Previous: "/users?page=1",
+			Self:     "/users?page=2",
+			First:    "/users?page=1",
+			Last:     "/users?page=5",
+		},
+		err: nil,
+	},
+	{
+		name:   "Successful link header parsing - github style header",
+		header: `<https://api.github.com/repositories/1/issues?page=2>; rel="next",<https://api.github.com/repositories/1/issues?page=9>; rel="last"`,
+		want: &pagination.Links{
+			Next: "https://api.github.com/repositories/1/issues?page=2",
+			Last: "https://api.github.com/repositories/1/issues?page=9",
+		},
+		err: nil,
+	},
+	{
+		name:   "Successful link header parsing - commas, multiple relations and other parameters",
+		header: `</users?status=open,closed&page=1>; title="Page 1, \"first\""; REL="First Prev" ; rel=next, </docs>; rel=help, </users?page=4>;rel=Last`,
+		want: &pagination.Links{
+			Previous: "/users?status=open,closed&page=1",
+			First:    "/users?status=open,closed&page=1",
+			Last:     "/users?page=4",
+		},
+		err: nil,
+	},
+	{
+		name:   "A failed link header parsing - missing angle brackets",
+		header: `/users?page=2; rel="next"`,
+		want:   nil,
+		err:    pagination.ErrLinkHeaderMalformed,
+	},
+	{
+		name:   "A failed link header parsing - unterminated target",
+		header: `</users?page=2; rel="next"`,
+		want:   nil,
+		err:    pagination.ErrLinkHeaderMalformed,
+	},
+	{
+		name:   "A failed link header parsing - unterminated quoted value",
+		header: `</users?page=2>; rel="next`,
+		want:   nil,
+		err:    pagination.ErrLinkHeaderMalformed,
+	},
+	{
+		name:   "A failed link header parsing - missing parameter separator",
+		header: `</users?page=2> rel="next"`,
+		want:   nil,
+		err:    pagination.ErrLinkHeaderMalformed,
+	},
+}
+
+// TestParseLinkHeader tests the paginator ParseLinkHeader method.
+func TestParseLinkHeader(t *testing.T) {
+	t.Log("ParseLinkHeader")
+
+	// Check each test case
+	for _, testcase := range parseLinkHeaderDataProvider {
+		t.Log(testcase.name)
+
+		got, err := pagination.ParseLinkHeader(testcase.header)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check links
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected links to be %v but got %v", testcase.want, got)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// KeyFunc returns the sort key values of a result row, in the order of the query sort columns.
+type KeyFunc func(row interface{}) []string
+
+// NewKeysetLinks creates keyset pagination links.
+// The first and last parameters are the sort key values of the first and last results of the page.
+// The links carry signed cursors instead of after and before positions when the optional schema has a cursor key.
+// Returns a next link positioned after the last result when the page is full, or has an extra result when the paginator looks ahead.
+// Returns a previous link positioned before the first result when the page is not the first one.
+func NewKeysetLinks(reqURL *url.URL, count int, first, last []string, schema ...*Schema) *Links {
+	return (*Paginator)(nil).NewKeysetLinks(reqURL, count, first, last, schema...)
+}
+
+// NewKeysetLinks creates keyset pagination links with the url parameters named by the paginator.
+// The links carry the maximum limit of the paginator instead of a limit above it.
+func (paginator *Paginator) NewKeysetLinks(reqURL *url.URL, count int, first, last []string, schema ...*Schema) *Links {
+	query := reqURL.Query()
+	links := &Links{Self: reqURL.String()}
+	hasMore := paginator.hasMore(count, paginator.clampLimit(query))
+	isForwards := len(query[paginator.getParam("after")]) != 0
+	isBackwards := len(query[paginator.getParam("before")]) != 0
+
+	if cursor, _ := paginator.getCursor(getSchema(schema), query); cursor != nil {
+		isForwards, isBackwards = !cursor.Backwards, cursor.Backwards
+	}
+
+	for _, param := range []string{"page", "after", "before", "cursor"} {
+		query.Del(paginator.getParam(param))
+	}
+	// Next Links
+	if count > 0 && (hasMore || isBackwards) {
+		reqURL.RawQuery = paginator.getKeysetQuery(query, last, false, getSchema(schema)).Encode()
+		links.Next = reqURL.String()
+	}
+	// Previous Links
+	if count > 0 && ((hasMore && isBackwards) || isForwards) {
+		reqURL.RawQuery = paginator.getKeysetQuery(query, first, true, getSchema(schema)).Encode()
+		links.Previous = reqURL.String()
+	}
+
+	return links
+}
+
+// NewKeysetPage creates a new keyset pagination page.
+// The results of a page requested with a before position are reversed back into the requested order.
+// The extra result fetched by a paginator looking ahead is removed from the page.
+// Returns a validation error if page creation was not successful.
+// Returns a pagination page if page creation was successful.
+func NewKeysetPage(reqURL *url.URL, result interface{}, key KeyFunc, schema ...*Schema) (*Page, error) {
+	return (*Paginator)(nil).NewKeysetPage(reqURL, result, key, schema...)
+}
+
+// NewKeysetPage creates a new keyset pagination page with the url parameters named by the paginator.
+func (paginator *Paginator) NewKeysetPage(reqURL *url.URL, result interface{}, key KeyFunc, schema ...*Schema) (*Page, error) {
+	if err := paginator.ValidateQuery(reqURL.Query(), schema...); err != nil {
+		return nil, err
+	}
+	// Check if result is a slice
+	aType := reflect.ValueOf(result)
+	if aType.Kind() != reflect.Slice {
+		return nil, errors.New("The provided collection is not a slice")
+	}
+
+	fetched := aType.Len()
+	aType = paginator.trimResults(aType, paginator.clampLimit(reqURL.Query()))
+	result = aType.Interface()
+
+	count := aType.Len()
+	cursor, _ := paginator.getCursor(getSchema(schema), reqURL.Query())
+	if len(reqURL.Query()[paginator.getParam("before")]) != 0 || (cursor != nil && cursor.Backwards) {
+		reversed := reflect.MakeSlice(aType.Type(), count, count)
+		for i := 0; i < count; i++ {
+			reversed.Index(i).Set(aType.Index(count - 1 - i))
+		}
+		aType = reversed
+		result = reversed.Interface()
+	}
+
+	var first, last []string
+	if count > 0 {
+		first = key(aType.Index(0).Interface())
+		last = key(aType.Index(count - 1).Interface())
+	}
+
+	page := &Page{Links: paginator.NewKeysetLinks(reqURL, fetched, first, last, schema...), Count: count, Results: result}
+	if paginator != nil && paginator.LookAhead {
+		page.HasNext, page.HasPrevious = boolPointer(page.Links.Next != ""), boolPointer(page.Links.Previous != "")
+	}
+
+	return page, nil
+}
+
+// getKeysetQuery returns a copy of the url parameters positioned after or before a keyset position.
+func (paginator *Paginator) getKeysetQuery(query url.Values, position []string, backwards bool, schema *Schema) url.Values {
+	positioned := url.Values{}
+	for param, values := range query {
+		positioned[param] = values
+	}
+
+	if schema != nil && len(schema.CursorKey) != 0 {
+		positioned.Set(paginator.getParam("cursor"), EncodeCursor(paginator.newCursor(query, position, backwards), schema.CursorKey))
+	} else if backwards {
+		positioned[paginator.getParam("before")] = position
+	} else {
+		positioned[paginator.getParam("after")] = position
+	}
+
+	return positioned
+}
+
+// getKeysetSearch returns the search condition selecting the results after or before a keyset position.
+// The position values are parameters of the types of their sort fields.
+// Returns nil when the query has no keyset position.
+func (query *Query) getKeysetSearch() *Search {
+	position, isBackwards := query.After, false
+	if len(query.Before) != 0 {
+		position, isBackwards = query.Before, true
+	}
+
+	columns := query.getSortColumns()
+	if len(position) == 0 || len(position) != len(columns) {
+		return nil
+	}
+
+	// Converts we validated before so we can ignore errors
+	values := make([]interface{}, len(position))
+	for i, column := range columns {
+		values[i], _ = query.Schema.parsePosition("", column.field, position[i])
+	}
+
+	var conditions []string
+	var parameters []interface{}
+	// Each condition requires the previous columns to be equal and the current column to be past the position
+	for i, column := range columns {
+		var comparisons []string
+		for j := 0; j < i; j++ {
+			comparisons = append(comparisons, columns[j].column+" = ?")
+			parameters = append(parameters, values[j])
+		}
+
+		operator := map[bool]string{true: " < ?", false: " > ?"}[column.desc != isBackwards]
+		comparisons = append(comparisons, column.column+operator)
+		parameters = append(parameters, values[i])
+		conditions = append(conditions, "("+strings.Join(comparisons, " AND ")+")")
+	}
+
+	return &Search{
+		SQL:        "(" + strings.Join(conditions, " OR ") + ")",
+		Parameters: parameters,
+	}
+}
+
+// joinSearches combines two optional searches into one that requires both.
+func joinSearches(search, other *Search) *Search {
+	if search == nil {
+		return other
+	}
+
+	if other == nil {
+		return search
+	}
+
+	return &Search{
+		SQL:        "(" + search.SQL + " AND " + other.SQL + ")",
+		Parameters: append(append([]interface{}{}, search.Parameters...), other.Parameters...),
+	}
+}
+
+// parsePosition converts a keyset position value to the Go type of its sort field.
+// Returns an invalid value error if the value is not valid for the field type.
+func (schema *Schema) parsePosition(parameter, field, value string) (interface{}, error) {
+	definition := schema.getField(field)
+
+	return (&Condition{Parameter: parameter, Field: field, Type: definition.Type, Enum: definition.Enum}).Parse(value)
+}
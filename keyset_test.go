@@ -0,0 +1,201 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// userKey returns the sort key values of a user.
+func userKey(row interface{}) []string {
+	return []string{strconv.FormatUint(row.(*User).ID, 10)}
+}
+
+// newKeysetLinksDataProvider provides data for the TestNewKeysetLinks function.
+var newKeysetLinksDataProvider = []struct {
+	name  string
+	url   string
+	count int
+	first []string
+	last  []string
+	links *pagination.Links
+}{
+	{
+		name:  "Successful keyset links creation - first page",
+		url:   "api.demo.com/v1/users?page=1&limit=3&order_by=id",
+		count: 3,
+		first: []string{"1"},
+		last:  []string{"3"},
+		links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?after=3&limit=3&order_by=id",
+			Previous: "",
+			Self:     "api.demo.com/v1/users?page=1&limit=3&order_by=id",
+		},
+	},
+	{
+		name:  "Successful keyset links creation - page after a position",
+		url:   "api.demo.com/v1/users?after=3&limit=3&order_by=id",
+		count: 3,
+		first: []string{"4"},
+		last:  []string{"6"},
+		links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?after=6&limit=3&order_by=id",
+			Previous: "api.demo.com/v1/users?before=4&limit=3&order_by=id",
+			Self:     "api.demo.com/v1/users?after=3&limit=3&order_by=id",
+		},
+	},
+	{
+		name:  "Successful keyset links creation - last page",
+		url:   "api.demo.com/v1/users?after=6&limit=3&order_by=id",
+		count: 2,
+		first: []string{"7"},
+		last:  []string{"8"},
+		links: &pagination.Links{
+			Next:     "",
+			Previous: "api.demo.com/v1/users?before=7&limit=3&order_by=id",
+			Self:     "api.demo.com/v1/users?after=6&limit=3&order_by=id",
+		},
+	},
+	{
+		name:  "Successful keyset links creation - page before a position",
+		url:   "api.demo.com/v1/users?before=7&limit=3&order_by=id",
+		count: 3,
+		first: []string{"4"},
+		last:  []string{"6"},
+		links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?after=6&limit=3&order_by=id",
+			Previous: "api.demo.com/v1/users?before=4&limit=3&order_by=id",
+			Self:     "api.demo.com/v1/users?before=7&limit=3&order_by=id",
+		},
+	},
+	{
+		name:  "Successful keyset links creation - first page before a position",
+		url:   "api.demo.com/v1/users?before=4&limit=3&order_by=id",
+		count: 2,
+		first: []string{"1"},
+		last:  []string{"2"},
+		links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?after=2&limit=3&order_by=id",
+			Previous: "",
+			Self:     "api.demo.com/v1/users?before=4&limit=3&order_by=id",
+		},
+	},
+	{
+		name:  "Successful keyset links creation - empty page",
+		url:   "api.demo.com/v1/users?after=8&limit=3&order_by=id",
+		count: 0,
+		links: &pagination.Links{
+			Next:     "",
+			Previous: "",
+			Self:     "api.demo.com/v1/users?after=8&limit=3&order_by=id",
+		},
+	},
+}
+
+// TestNewKeysetLinks tests the paginator NewKeysetLinks method.
+func TestNewKeysetLinks(t *testing.T) {
+	t.Log("NewKeysetLinks")
+	// Check each test case
+	for _, testcase := range newKeysetLinksDataProvider {
+		t.Log(testcase.name)
+
+		url, _ := url.Parse(testcase.url)
+		links := pagination.NewKeysetLinks(url, testcase.count, testcase.first, testcase.last)
+
+		// Check links
+		if !reflect.DeepEqual(testcase.links, links) {
+			t.Errorf("Expected links to be %v but got %v", testcase.links, links)
+		}
+	}
+}
+
+// newKeysetPageDataProvider provides data for the TestNewKeysetPage function.
+var newKeysetPageDataProvider = []struct {
+	name    string
+	url     string
+	results interface{}
+	want    *pagination.Page
+	err     error
+}{
+	{
+		name: "Keyset page creation fails - combined keyset positions",
+		url:  "api.demo.com/v1/users?after=1&before=3",
+		want: nil,
+		err:  &pagination.ValidationError{Parameter: "after", Value: "1", Err: pagination.ErrAfterWithBefore},
+	},
+	{
+		name:    "Keyset page creation fails - invalid results",
+		url:     "api.demo.com/v1/users?after=1",
+		results: "invalid results",
+		want:    nil,
+		err:     errors.New("The provided collection is not a slice"),
+	},
+	{
+		name: "Successful keyset page creation - page after a position",
+		url:  "api.demo.com/v1/users?after=1&limit=2&order_by=id",
+		results: []*User{
+			{ID: 2, Name: "Jill", Surname: "Doe"},
+			{ID: 3, Name: "Paul", Surname: "Johnson"},
+		},
+		want: &pagination.Page{
+			Count: 2,
+			Links: &pagination.Links{
+				Next:     "api.demo.com/v1/users?after=3&limit=2&order_by=id",
+				Previous: "api.demo.com/v1/users?before=2&limit=2&order_by=id",
+				Self:     "api.demo.com/v1/users?after=1&limit=2&order_by=id",
+			},
+			Results: []*User{
+				{ID: 2, Name: "Jill", Surname: "Doe"},
+				{ID: 3, Name: "Paul", Surname: "Johnson"},
+			},
+		},
+		err: nil,
+	},
+	{
+		name: "Successful keyset page creation - page before a position",
+		url:  "api.demo.com/v1/users?before=4&limit=2&order_by=id",
+		results: []*User{
+			{ID: 3, Name: "Paul", Surname: "Johnson"},
+			{ID: 2, Name: "Jill", Surname: "Doe"},
+		},
+		want: &pagination.Page{
+			Count: 2,
+			Links: &pagination.Links{
+				Next:     "api.demo.com/v1/users?after=3&limit=2&order_by=id",
+				Previous: "api.demo.com/v1/users?before=2&limit=2&order_by=id",
+				Self:     "api.demo.com/v1/users?before=4&limit=2&order_by=id",
+			},
+			Results: []*User{
+				{ID: 2, Name: "Jill", Surname: "Doe"},
+				{ID: 3, Name: "Paul", Surname: "Johnson"},
+			},
+		},
+		err: nil,
+	},
+}
+
+// TestNewKeysetPage tests the paginator NewKeysetPage method.
+func TestNewKeysetPage(t *testing.T) {
+	t.Log("NewKeysetPage")
+	// Check each test case
+	for _, testcase := range newKeysetPageDataProvider {
+		t.Log(testcase.name)
+
+		url, _ := url.Parse(testcase.url)
+		got, err := pagination.NewKeysetPage(url, testcase.results, userKey)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check page
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected page to be %v but got %v", testcase.want, got)
+		}
+	}
+}
@@ -6,33 +6,81 @@ import (
 )
 
 // Links is a pagination Links structure.
+// The first and last links are only set when the total number of results is known.
 type Links struct {
 	Next     string `json:"next"`
 	Previous string `json:"previous"`
 	Self     string `json:"self"`
+	First    string `json:"first,omitempty"`
+	Last     string `json:"last,omitempty"`
 }
 
 // NewLinks creates pagination links.
 func NewLinks(reqURL *url.URL, count int) *Links {
+	return (*Paginator)(nil).NewLinks(reqURL, count)
+}
+
+// NewLinks creates pagination links with the url parameters named by the paginator.
+// The links carry the maximum limit of the paginator instead of a limit above it.
+func (paginator *Paginator) NewLinks(reqURL *url.URL, count int) *Links {
+	return paginator.newLinks(reqURL, count, -1)
+}
+
+// newLinks creates pagination links of a total number of results, which is unknown when negative.
+// Returns a next link when the page is full, or has an extra record when looking ahead, if the total is unknown.
+// The page is full when it reaches the limit url parameter, or the default limit of the paginator without one.
+// Returns a next link when the page is not the last page if the total is known.
+// Returns first and last links, and a next link when not requesting a particular page, if the total is known.
+func (paginator *Paginator) newLinks(reqURL *url.URL, count, total int) *Links {
 	query := reqURL.Query()
+	pageParam := paginator.getParam("page")
 	Links := &Links{Self: reqURL.String()}
-	page, err := strconv.ParseInt(query.Get("page"), 10, 64)
+	page, err := strconv.ParseInt(query.Get(pageParam), 10, 64)
+	// A known total numbers the pages of a query without a page or keyset position
+	if err != nil && total >= 0 && !paginator.isKeyset(query) {
+		page, err = 1, nil
+	}
 	// A page number is given
 	if err == nil {
 		// Next Links
-		limit, err := strconv.ParseInt(query.Get("limit"), 10, 64)
-		if err == nil && int64(count) >= limit {
-			query.Set("page", strconv.Itoa(int(page+1)))
+		_, err := strconv.Atoi(query.Get(paginator.getParam("limit")))
+		hasLimit := err == nil || (paginator != nil && paginator.DefaultLimit > 0)
+		limit := paginator.clampLimit(query)
+		if (total < 0 && hasLimit && paginator.hasMore(count, limit)) || (total >= 0 && page < int64(getTotalPages(total, limit))) {
+			query.Set(pageParam, strconv.Itoa(int(page+1)))
 			reqURL.RawQuery = query.Encode()
 			Links.Next = reqURL.String()
 		}
 		// Previous Links
 		if page > 1 {
-			query.Set("page", strconv.Itoa(int(page-1)))
+			query.Set(pageParam, strconv.Itoa(int(page-1)))
 			reqURL.RawQuery = query.Encode()
 			Links.Previous = reqURL.String()
 		}
+		// First and Last Links
+		if total >= 0 {
+			query.Set(pageParam, "1")
+			reqURL.RawQuery = query.Encode()
+			Links.First = reqURL.String()
+
+			if lastPage := getTotalPages(total, limit); lastPage > 1 {
+				query.Set(pageParam, strconv.Itoa(lastPage))
+			}
+			reqURL.RawQuery = query.Encode()
+			Links.Last = reqURL.String()
+		}
 	}
 
 	return Links
 }
+
+// isKeyset reports whether the url parameters hold a keyset position or a cursor.
+func (paginator *Paginator) isKeyset(query url.Values) bool {
+	for _, param := range []string{"after", "before", "cursor"} {
+		if len(query[paginator.getParam(param)]) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,240 @@
+package pagination
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Condition is a pagination condition structure passed to search operators.
+// A condition has at least one value, the repeated url parameter values or the single value of a filter condition.
+type Condition struct {
+	Parameter string
+	Field     string
+	Column    string
+	Type      FieldType
+	Enum      []string
+	Values    []string
+	Dialect   Dialect
+}
+
+// Operator returns the SQL, with question mark placeholders, and the parameters of a search condition.
+// Returns an error if the condition values are not valid for the operator.
+type Operator func(condition *Condition) (sql string, parameters []interface{}, err error)
+
+var (
+	// operatorsMutex guards the registered operators.
+	operatorsMutex sync.RWMutex
+	// operators are the registered search operators.
+	operators = map[string]Operator{
+		"equals":        compare("="),
+		"notequals":     compare("!="),
+		"greaterthan":   compare(">"),
+		"lessthan":      compare("<"),
+		"gthanorequals": compare(">="),
+		"lthanorequals": compare("<="),
+		"startswith":    like(false, "", "%"),
+		"endswith":      like(false, "%", ""),
+		"contains":      like(false, "%", "%"),
+		"like":          rawLike,
+		"iequals":       iequals,
+		"istartswith":   like(true, "", "%"),
+		"iendswith":     like(true, "%", ""),
+		"icontains":     like(true, "%", "%"),
+		"after":         compare(">"),
+		"before":        compare("<"),
+		"year":          datePart("year"),
+		"month":         datePart("month"),
+		"day":           datePart("day"),
+		"in":            in("IN"),
+		"notin":         in("NOT IN"),
+		"between":       between,
+		"isnull":        isNull(true),
+		"isnotnull":     isNull(false),
+	}
+)
+
+// RegisterOperator registers a search operator for all searches, replacing any operator with the same name.
+// Panics if the name is empty, or is "not" since it is reserved for the negation modifier.
+func RegisterOperator(name string, operator Operator) {
+	if name == "" || name == "not" {
+		panic("pagination: invalid operator name '" + name + "'")
+	}
+
+	operatorsMutex.Lock()
+	defer operatorsMutex.Unlock()
+
+	operators[name] = operator
+}
+
+// GetOperator returns the search operator with a name.
+// Returns the schema operator with the name if any, and the registered operator otherwise.
+// Returns false if no operator has the name.
+func (schema *Schema) GetOperator(name string) (Operator, bool) {
+	if schema != nil && schema.Operators[name] != nil {
+		return schema.Operators[name], true
+	}
+
+	operatorsMutex.RLock()
+	defer operatorsMutex.RUnlock()
+
+	operator, ok := operators[name]
+
+	return operator, ok && operator != nil
+}
+
+// compare returns an operator comparing a column with the first value parsed for the field type.
+func compare(comparison string) Operator {
+	return func(condition *Condition) (string, []interface{}, error) {
+		parameter, err := condition.Parse(condition.Values[0])
+		if err != nil {
+			return "", nil, err
+		}
+
+		return "(" + condition.Column + " " + comparison + " ?)", []interface{}{parameter}, nil
+	}
+}
+
+// like returns an operator matching a column with the escaped first value between a prefix and a suffix.
+func like(isCaseInsensitive bool, prefix, suffix string) Operator {
+	return func(condition *Condition) (string, []interface{}, error) {
+		parameter := prefix + condition.Dialect.EscapeLike(condition.Values[0]) + suffix
+
+		if isCaseInsensitive {
+			return "(" + condition.Dialect.ILike(condition.Column, "?") + ")", []interface{}{parameter}, nil
+		}
+
+		return "(" + condition.Dialect.Like(condition.Column, "?") + ")", []interface{}{parameter}, nil
+	}
+}
+
+// rawLike matches a column with the first value as a pattern.
+func rawLike(condition *Condition) (string, []interface{}, error) {
+	return "(" + condition.Dialect.Like(condition.Column, "?") + ")", []interface{}{condition.Values[0]}, nil
+}
+
+// iequals compares a column with the first value regardless of case.
+func iequals(condition *Condition) (string, []interface{}, error) {
+	return "(LOWER(" + condition.Column + ") = LOWER(?))", []interface{}{condition.Values[0]}, nil
+}
+
+// datePart returns an operator comparing a date part of a column with the first value.
+// The value is parsed as an int when the field has a type.
+func datePart(part string) Operator {
+	return func(condition *Condition) (string, []interface{}, error) {
+		var parameter interface{} = condition.Values[0]
+		if condition.Type != "" {
+			var err error
+			if parameter, err = condition.ParseAs(TypeInt, condition.Values[0]); err != nil {
+				return "", nil, err
+			}
+		}
+
+		return "(" + condition.Dialect.DatePart(part, condition.Column) + " = ?)", []interface{}{parameter}, nil
+	}
+}
+
+// in returns an operator checking the membership of a column in the list of values parsed for the field type.
+func in(membership string) Operator {
+	return func(condition *Condition) (string, []interface{}, error) {
+		list, err := getListValues(condition)
+		if err != nil {
+			return "", nil, err
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(list)), ", ")
+
+		return "(" + condition.Column + " " + membership + " (" + placeholders + "))", list, nil
+	}
+}
+
+// between checks that a column is between the two ordered bounds of the list of values parsed for the field type.
+// Returns a search operation error if the values are not two ordered bounds.
+func between(condition *Condition) (string, []interface{}, error) {
+	var bounds []string
+	for _, value := range condition.Values {
+		bounds = append(bounds, strings.Split(value, ",")...)
+	}
+
+	if len(bounds) != 2 || bounds[0] == "" || bounds[1] == "" {
+		return "", nil, errors.New("Search operation 'between' requires two values")
+	}
+
+	parameters, err := getListValues(condition)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !isOrdered(parameters[0], parameters[1]) {
+		return "", nil, errors.New("Search operation 'between' requires ordered values")
+	}
+
+	return "(" + condition.Column + " BETWEEN ? AND ?)", parameters, nil
+}
+
+// isNull returns an operator checking whether a column is null, or is not null, when the first value is true.
+// The operator returns a search operation error if the first value is not a boolean.
+func isNull(isNullWhenTrue bool) Operator {
+	return func(condition *Condition) (string, []interface{}, error) {
+		value, err := strconv.ParseBool(condition.Values[0])
+		if err != nil {
+			name := map[bool]string{true: "isnull", false: "isnotnull"}[isNullWhenTrue]
+			return "", nil, errors.New("Search operation '" + name + "' requires a true or false value")
+		}
+
+		return "(" + condition.Column + map[bool]string{true: " IS NULL)", false: " IS NOT NULL)"}[value == isNullWhenTrue], nil, nil
+	}
+}
+
+// getListValues returns the comma separated or repeated values of a list operation parsed for the field type.
+// Returns an invalid value error if a value is not valid for the field type.
+func getListValues(condition *Condition) ([]interface{}, error) {
+	var list []interface{}
+
+	for _, value := range condition.Values {
+		for _, item := range strings.Split(value, ",") {
+			parsed, err := condition.Parse(item)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, parsed)
+		}
+	}
+
+	return list, nil
+}
+
+// isOrdered reports whether a lower bound is not greater than an upper bound.
+// Untyped bounds are compared as numbers or dates when both of them are numbers or dates, and as strings otherwise.
+func isOrdered(lower, upper interface{}) bool {
+	switch lowerBound := lower.(type) {
+	case int64:
+		return lowerBound <= upper.(int64)
+	case float64:
+		return lowerBound <= upper.(float64)
+	case time.Time:
+		return !upper.(time.Time).Before(lowerBound)
+	case string:
+		upperBound := upper.(string)
+		lowerNumber, lowerErr := strconv.ParseFloat(lowerBound, 64)
+		upperNumber, upperErr := strconv.ParseFloat(upperBound, 64)
+
+		if lowerErr == nil && upperErr == nil {
+			return lowerNumber <= upperNumber
+		}
+
+		lowerTime, lowerErr := parseTime(lowerBound)
+		upperTime, upperErr := parseTime(upperBound)
+
+		if lowerErr == nil && upperErr == nil {
+			return !upperTime.Before(lowerTime)
+		}
+
+		return lowerBound <= upperBound
+	}
+
+	return true
+}
@@ -0,0 +1,173 @@
+package pagination_test
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// hasAny checks whether a comma separated tags column has any of the values.
+func hasAny(condition *pagination.Condition) (string, []interface{}, error) {
+	return "(FIND_IN_SET(?, " + condition.Column + ") > 0)", []interface{}{condition.Values[0]}, nil
+}
+
+// inCIDR checks whether an ip column is in the network of the value.
+func inCIDR(condition *pagination.Condition) (string, []interface{}, error) {
+	_, network, err := net.ParseCIDR(condition.Values[0])
+	if err != nil {
+		return "", nil, errors.New("Search operation 'incidr' requires a CIDR value")
+	}
+
+	return "(" + condition.Column + " << ?::inet)", []interface{}{network.String()}, nil
+}
+
+// tagCount compares the number of tags of a comma separated tags column with the value as an int.
+func tagCount(condition *pagination.Condition) (string, []interface{}, error) {
+	count, err := condition.ParseAs(pagination.TypeInt, condition.Values[0])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "(LENGTH(" + condition.Column + ") - LENGTH(REPLACE(" + condition.Column + ", ',', '')) + 1 = ?)", []interface{}{count}, nil
+}
+
+// operatorSearchDataProvider provides data for the TestOperatorSearch function.
+var operatorSearchDataProvider = []struct {
+	name   string
+	query  string
+	schema *pagination.Schema
+	want   *pagination.Search
+	err    error
+}{
+	{
+		name:   "Successful search creation - registered operator",
+		query:  "tags__hasany=go",
+		schema: nil,
+		want: &pagination.Search{
+			SQL:        "((FIND_IN_SET(?, tags) > 0))",
+			Parameters: []interface{}{"go"},
+		},
+		err: nil,
+	},
+	{
+		name:   "Successful search creation - negated registered operator",
+		query:  "tags__not__hasany=go",
+		schema: nil,
+		want: &pagination.Search{
+			SQL:        "((NOT (FIND_IN_SET(?, tags) > 0)))",
+			Parameters: []interface{}{"go"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - schema operator",
+		query: "ip__incidr=10.0.0.1/8",
+		schema: &pagination.Schema{
+			Fields:    map[string]pagination.Field{"ip": {Column: "hosts.ip"}},
+			Operators: map[string]pagination.Operator{"incidr": inCIDR},
+			Dialect:   pagination.PostgreSQL,
+		},
+		want: &pagination.Search{
+			SQL:        `(("hosts"."ip" << $1::inet))`,
+			Parameters: []interface{}{"10.0.0.0/8"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - schema operator replacing a built-in operator",
+		query: "name__equals=ammar",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{"name": {}},
+			Operators: map[string]pagination.Operator{"equals": func(condition *pagination.Condition) (string, []interface{}, error) {
+				return "(" + condition.Column + " <=> ?)", []interface{}{condition.Values[0]}, nil
+			}},
+		},
+		want: &pagination.Search{
+			SQL:        "((`name` <=> ?))",
+			Parameters: []interface{}{"ammar"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - invalid schema operator value",
+		query: "ip__incidr=10.0.0.1",
+		schema: &pagination.Schema{
+			Fields:    map[string]pagination.Field{"ip": {}},
+			Operators: map[string]pagination.Operator{"incidr": inCIDR},
+		},
+		want: nil,
+		err:  &pagination.ValidationError{Parameter: "ip__incidr", Value: "10.0.0.1", Err: pagination.ErrInvalidValue, Message: "Search operation 'incidr' requires a CIDR value"},
+	},
+	{
+		name:   "A failed search creation - operator of another schema",
+		query:  "ip__incidr=10.0.0.1/8",
+		schema: nil,
+		want:   nil,
+		err:    &pagination.ValidationError{Parameter: "ip__incidr", Value: "10.0.0.1/8", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'incidr'"},
+	},
+}
+
+// TestOperatorSearch tests the paginator NewSearch method with custom operators.
+func TestOperatorSearch(t *testing.T) {
+	t.Log("NewSearch with custom operators")
+
+	pagination.RegisterOperator("hasany", hasAny)
+	// Check each test case
+	for _, testcase := range operatorSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query, testcase.schema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// TestOperatorSearchComponents tests the paginator GetSearchComponents method with a custom operator.
+func TestOperatorSearchComponents(t *testing.T) {
+	t.Log("GetSearchComponents with a custom operator")
+
+	pagination.RegisterOperator("tagcount", tagCount)
+	condition, parameter := pagination.GetSearchComponents("tags", "tagcount", "3")
+
+	// Check condition
+	if condition != "" {
+		t.Errorf("Expected response to be %s but got %s", "", condition)
+	}
+
+	// Check parameter
+	if parameter != "" {
+		t.Errorf("Expected parameter to be %s but got %s", "", parameter)
+	}
+}
+
+// TestRegisterReservedOperator tests the paginator RegisterOperator method with reserved names.
+func TestRegisterReservedOperator(t *testing.T) {
+	t.Log("RegisterOperator with reserved names")
+	// Check each reserved name
+	for _, name := range []string{"", "not"} {
+		t.Log(name)
+
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected registering '%s' to panic", name)
+				}
+			}()
+
+			pagination.RegisterOperator(name, hasAny)
+		}()
+	}
+}
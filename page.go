@@ -4,20 +4,53 @@ import (
 	"errors"
 	"net/url"
 	"reflect"
+	"strconv"
 )
 
 // Page is a pagination page structure.
+// The total, total pages, current page and page size are only set when the total number of results is known.
+// HasNext and HasPrevious report whether the page has next and previous links, and are only set by a paginator looking ahead.
 type Page struct {
-	Links   *Links      `json:"_links"`
-	Count   int         `json:"count"`
-	Results interface{} `json:"results"`
+	Links       *Links      `json:"_links"`
+	Count       int         `json:"count"`
+	Total       *int        `json:"total,omitempty"`
+	TotalPages  *int        `json:"total_pages,omitempty"`
+	CurrentPage int         `json:"current_page,omitempty"`
+	PageSize    int         `json:"page_size,omitempty"`
+	HasNext     *bool       `json:"has_next,omitempty"`
+	HasPrevious *bool       `json:"has_previous,omitempty"`
+	Results     interface{} `json:"results"`
 }
 
 // NewPage creates a new pagination page.
+// The extra result fetched by a paginator looking ahead is removed from the page.
+// The url parameters are validated against the optional schema, like those of NewQuery.
 // Returns a validation error if page creation was not successful.
 // Returns a pagination page if page creation was successful.
-func NewPage(reqURL *url.URL, result interface{}) (*Page, error) {
-	if err := ValidateQuery(reqURL.Query()); err != nil {
+func NewPage(reqURL *url.URL, result interface{}, schema ...*Schema) (*Page, error) {
+	return (*Paginator)(nil).NewPage(reqURL, result, schema...)
+}
+
+// NewPage creates a new pagination page with the url parameters named by the paginator.
+func (paginator *Paginator) NewPage(reqURL *url.URL, result interface{}, schema ...*Schema) (*Page, error) {
+	return paginator.newPage(reqURL, result, -1, schema...)
+}
+
+// NewPageWithTotal creates a new pagination page of a known total number of results.
+// Returns a page with its total, total pages, current page, page size, and first and last links.
+// Returns a page without them when the total is negative, like NewPage.
+func NewPageWithTotal(reqURL *url.URL, result interface{}, total int, schema ...*Schema) (*Page, error) {
+	return (*Paginator)(nil).NewPageWithTotal(reqURL, result, total, schema...)
+}
+
+// NewPageWithTotal creates a new pagination page of a known total number of results with the url parameters named by the paginator.
+func (paginator *Paginator) NewPageWithTotal(reqURL *url.URL, result interface{}, total int, schema ...*Schema) (*Page, error) {
+	return paginator.newPage(reqURL, result, total, schema...)
+}
+
+// newPage creates a new pagination page of a total number of results, which is unknown when negative.
+func (paginator *Paginator) newPage(reqURL *url.URL, result interface{}, total int, schema ...*Schema) (*Page, error) {
+	if err := paginator.ValidateQuery(reqURL.Query(), schema...); err != nil {
 		return nil, err
 	}
 	// Check if result is a slice
@@ -26,5 +59,55 @@ func NewPage(reqURL *url.URL, result interface{}) (*Page, error) {
 		return nil, errors.New("The provided collection is not a slice")
 	}
 
-	return &Page{Links: NewLinks(reqURL, aType.Len()), Count: aType.Len(), Results: result}, nil
+	query := reqURL.Query()
+	fetched := aType.Len()
+	aType = paginator.trimResults(aType, paginator.clampLimit(query))
+
+	page := &Page{Links: paginator.newLinks(reqURL, fetched, total), Count: aType.Len(), Results: aType.Interface()}
+	if paginator != nil && paginator.LookAhead {
+		page.HasNext, page.HasPrevious = boolPointer(page.Links.Next != ""), boolPointer(page.Links.Previous != "")
+	}
+
+	if total >= 0 {
+		limit := paginator.clampLimit(query)
+		totalPages := getTotalPages(total, limit)
+		page.Total, page.TotalPages = &total, &totalPages
+		page.CurrentPage, page.PageSize = paginator.getCurrentPage(query), limit
+	}
+
+	return page, nil
+}
+
+// getCurrentPage returns the page number of the url parameters, the first page when not requesting a particular page.
+// Returns 0 for a keyset position or cursor, which is not a numbered page.
+func (paginator *Paginator) getCurrentPage(query url.Values) int {
+	if paginator.isKeyset(query) {
+		return 0
+	}
+
+	page, err := strconv.Atoi(query.Get(paginator.getParam("page")))
+	if err != nil || page < 1 {
+		return 1
+	}
+
+	return page
+}
+
+// trimResults returns the results without the extra result fetched by a paginator looking ahead.
+func (paginator *Paginator) trimResults(results reflect.Value, limit int) reflect.Value {
+	if paginator != nil && paginator.LookAhead && results.Len() > limit {
+		return results.Slice(0, limit)
+	}
+
+	return results
+}
+
+// getTotalPages returns the number of pages of a limit needed for a total number of results.
+func getTotalPages(total, limit int) int {
+	return (total + limit - 1) / limit
+}
+
+// boolPointer returns a pointer to a bool.
+func boolPointer(value bool) *bool {
+	return &value
 }
@@ -28,7 +28,7 @@ var newPageDataProvider = []struct {
 		name: "Page creation fails - invalid url query",
 		url:  "api.demo.com/v1/users?page=invalid_page&limit=invalid_limit&order_by=&order=invalid_order",
 		want: nil,
-		err:  errors.New("Page is invalid"),
+		err:  &pagination.ValidationError{Parameter: "page", Value: "invalid_page", Err: pagination.ErrInvalidPage},
 	},
 	{
 		name:    "Page creation fails - invalid results",
@@ -180,7 +180,169 @@ func TestNewPage(t *testing.T) {
 
 		// Check page
 		if !reflect.DeepEqual(testcase.want, got) {
-			t.Errorf("Expected page to be %q but got %q", testcase.want, got)
+			t.Errorf("Expected page to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// TestNewPageWithSchema tests the paginator NewPage method with a schema.
+func TestNewPageWithSchema(t *testing.T) {
+	t.Log("NewPage with a schema")
+
+	schema := &pagination.Schema{Sorts: map[string]string{"author-name": "authors.name"}}
+	reqURL, _ := url.Parse("api.demo.com/v1/users?page=1&limit=3&order_by=author-name")
+
+	// Check error
+	if _, err := pagination.NewPage(reqURL, []*User{}, schema); err != nil {
+		t.Errorf("Expected error to be %v but got %v", nil, err)
+	}
+
+	// Check error without the schema
+	want := &pagination.ValidationError{Parameter: "order_by", Value: "author-name", Err: pagination.ErrInvalidOrderBy}
+	if _, err := pagination.NewPage(reqURL, []*User{}); !reflect.DeepEqual(want, err) {
+		t.Errorf("Expected error to be %v but got %v", want, err)
+	}
+}
+
+// intPointer returns a pointer to an int.
+func intPointer(value int) *int {
+	return &value
+}
+
+// boolPointer returns a pointer to a bool.
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+// newPageWithTotalDataProvider provides data for the TestNewPageWithTotal function.
+var newPageWithTotalDataProvider = []struct {
+	name    string
+	url     string
+	results interface{}
+	total   int
+	want    *pagination.Page
+	err     error
+}{
+	{
+		name:    "Successful page creation - middle page of a known total",
+		url:     "api.demo.com/v1/users?page=3&limit=2",
+		results: []*User{{ID: 5, Name: "John"}, {ID: 6, Name: "Jill"}},
+		total:   9,
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Next:     "api.demo.com/v1/users?limit=2&page=4",
+				Previous: "api.demo.com/v1/users?limit=2&page=2",
+				Self:     "api.demo.com/v1/users?page=3&limit=2",
+				First:    "api.demo.com/v1/users?limit=2&page=1",
+				Last:     "api.demo.com/v1/users?limit=2&page=5",
+			},
+			Count:       2,
+			Total:       intPointer(9),
+			TotalPages:  intPointer(5),
+			CurrentPage: 3,
+			PageSize:    2,
+			Results:     []*User{{ID: 5, Name: "John"}, {ID: 6, Name: "Jill"}},
+		},
+		err: nil,
+	},
+	{
+		name:    "Successful page creation - full last page of a known total",
+		url:     "api.demo.com/v1/users?page=2&limit=2",
+		results: []*User{{ID: 3, Name: "John"}, {ID: 4, Name: "Jill"}},
+		total:   4,
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Next:     "",
+				Previous: "api.demo.com/v1/users?limit=2&page=1",
+				Self:     "api.demo.com/v1/users?page=2&limit=2",
+				First:    "api.demo.com/v1/users?limit=2&page=1",
+				Last:     "api.demo.com/v1/users?limit=2&page=2",
+			},
+			Count:       2,
+			Total:       intPointer(4),
+			TotalPages:  intPointer(2),
+			CurrentPage: 2,
+			PageSize:    2,
+			Results:     []*User{{ID: 3, Name: "John"}, {ID: 4, Name: "Jill"}},
+		},
+		err: nil,
+	},
+	{
+		name:    "Successful page creation - no paging, known total",
+		url:     "api.demo.com/v1/users",
+		results: []*User{{ID: 1, Name: "John"}},
+		total:   31,
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Next:     "api.demo.com/v1/users?page=2",
+				Previous: "",
+				Self:     "api.demo.com/v1/users",
+				First:    "api.demo.com/v1/users?page=1",
+				Last:     "api.demo.com/v1/users?page=2",
+			},
+			Count:       1,
+			Total:       intPointer(31),
+			TotalPages:  intPointer(2),
+			CurrentPage: 1,
+			PageSize:    30,
+			Results:     []*User{{ID: 1, Name: "John"}},
+		},
+		err: nil,
+	},
+	{
+		name:    "Successful page creation - empty known total",
+		url:     "api.demo.com/v1/users?page=1&limit=2",
+		results: []*User{},
+		total:   0,
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Self:  "api.demo.com/v1/users?page=1&limit=2",
+				First: "api.demo.com/v1/users?limit=2&page=1",
+				Last:  "api.demo.com/v1/users?limit=2&page=1",
+			},
+			Count:       0,
+			Total:       intPointer(0),
+			TotalPages:  intPointer(0),
+			CurrentPage: 1,
+			PageSize:    2,
+			Results:     []*User{},
+		},
+		err: nil,
+	},
+	{
+		name:    "Successful page creation - unknown total",
+		url:     "api.demo.com/v1/users?page=1&limit=2",
+		results: []*User{{ID: 1, Name: "John"}},
+		total:   -1,
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Self: "api.demo.com/v1/users?page=1&limit=2",
+			},
+			Count:   1,
+			Results: []*User{{ID: 1, Name: "John"}},
+		},
+		err: nil,
+	},
+}
+
+// TestNewPageWithTotal tests the paginator NewPageWithTotal method.
+func TestNewPageWithTotal(t *testing.T) {
+	t.Log("NewPageWithTotal")
+	// Check each test case
+	for _, testcase := range newPageWithTotalDataProvider {
+		t.Log(testcase.name)
+
+		url, _ := url.Parse(testcase.url)
+		got, err := pagination.NewPageWithTotal(url, testcase.results, testcase.total)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check page
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected page to be %v but got %v", testcase.want, got)
 		}
 	}
 }
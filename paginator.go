@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// OverflowPolicy is the policy applied to a requested limit above the maximum limit.
+type OverflowPolicy int
+
+const (
+	// ClampOverflow replaces a limit above the maximum limit with the maximum limit.
+	ClampOverflow OverflowPolicy = iota
+	// RejectOverflow rejects a limit above the maximum limit with a limit is too large error.
+	RejectOverflow
+)
+
+// Paginator is a pagination configuration structure holding the names of the url parameters and the page size limits.
+// Empty names use the default parameter names, and an empty separator uses the default "__" search separator.
+// A zero default limit uses a default limit of 30, and a zero maximum limit does not limit the page size.
+// A paginator looking ahead fetches one more record than the limit, and the links and pages it creates take the extra record as the proof of a next page.
+// A nil paginator uses the defaults, which are used by the package functions.
+type Paginator struct {
+	PageParam           string
+	LimitParam          string
+	OrderByParam        string
+	OrderParam          string
+	SearchOperatorParam string
+	AfterParam          string
+	BeforeParam         string
+	CursorParam         string
+	FilterParam         string
+	Separator           string
+	DefaultLimit        int
+	MaxLimit            int
+	Overflow            OverflowPolicy
+	LookAhead           bool
+}
+
+// getParam returns the configured name of a url parameter given by its default name.
+func (paginator *Paginator) getParam(param string) string {
+	if paginator == nil {
+		return param
+	}
+
+	configured := map[string]string{
+		"page":           paginator.PageParam,
+		"limit":          paginator.LimitParam,
+		"order_by":       paginator.OrderByParam,
+		"order":          paginator.OrderParam,
+		"searchOperator": paginator.SearchOperatorParam,
+		"after":          paginator.AfterParam,
+		"before":         paginator.BeforeParam,
+		"cursor":         paginator.CursorParam,
+		"filter":         paginator.FilterParam,
+	}[param]
+
+	if configured == "" {
+		return param
+	}
+
+	return configured
+}
+
+// getSeparator returns the separator of the field, modifier and operation of a search parameter.
+func (paginator *Paginator) getSeparator() string {
+	if paginator == nil || paginator.Separator == "" {
+		return "__"
+	}
+
+	return paginator.Separator
+}
+
+// getLimit returns the limit used for a requested limit.
+// Returns the default limit when requesting for less than 1 record, and the maximum limit when requesting for more.
+func (paginator *Paginator) getLimit(limit int) int {
+	if limit < 1 && paginator != nil && paginator.DefaultLimit > 0 {
+		limit = paginator.DefaultLimit
+	} else if limit < 1 {
+		limit = 30
+	}
+
+	if paginator != nil && paginator.MaxLimit > 0 && limit > paginator.MaxLimit {
+		return paginator.MaxLimit
+	}
+
+	return limit
+}
+
+// clampLimit returns the limit used for the limit url parameter, and replaces a limit above the maximum limit with the maximum limit.
+func (paginator *Paginator) clampLimit(query url.Values) int {
+	limitParam := paginator.getParam("limit")
+	requested, _ := strconv.Atoi(query.Get(limitParam))
+
+	limit := paginator.getLimit(requested)
+	if requested > limit {
+		query.Set(limitParam, strconv.Itoa(limit))
+	}
+
+	return limit
+}
+
+// hasMore reports whether a number of fetched records shows that there are records after a page of a limit.
+// Returns true for a full page, or for a page with an extra record when the paginator looks ahead.
+func (paginator *Paginator) hasMore(count, limit int) bool {
+	if paginator != nil && paginator.LookAhead {
+		return count > limit
+	}
+
+	return count >= limit
+}
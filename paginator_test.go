@@ -0,0 +1,406 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// styleGuidePaginator is a paginator using the parameter names of an api style guide.
+var styleGuidePaginator = &pagination.Paginator{
+	LimitParam:          "page_size",
+	OrderByParam:        "sort",
+	SearchOperatorParam: "match",
+	FilterParam:         "q",
+	Separator:           ".",
+}
+
+// clampingPaginator is a paginator clamping limits above its maximum limit.
+var clampingPaginator = &pagination.Paginator{DefaultLimit: 20, MaxLimit: 100}
+
+// rejectingPaginator is a paginator rejecting limits above its maximum limit.
+var rejectingPaginator = &pagination.Paginator{DefaultLimit: 20, MaxLimit: 100, Overflow: pagination.RejectOverflow}
+
+// paginatorQueryDataProvider provides data for the TestPaginatorNewQuery function.
+var paginatorQueryDataProvider = []struct {
+	name      string
+	paginator *pagination.Paginator
+	query     string
+	got       *pagination.Query
+	err       error
+}{
+	{
+		name:      "Successful query creation - configured parameter names",
+		paginator: styleGuidePaginator,
+		query:     "page=2&page_size=5&sort=-age&match=or&name.equals=ammar&age.not.lessthan=18",
+		got: &pagination.Query{
+			Page:    2,
+			Limit:   5,
+			OrderBy: "-age",
+			Sort:    []pagination.SortTerm{{Field: "age", Desc: true}},
+			Search: &pagination.Search{
+				SQL:        "((NOT (age < ?)) OR (name = ?))",
+				Parameters: []interface{}{"18", "ammar"},
+			},
+			Paginator: styleGuidePaginator,
+		},
+		err: nil,
+	},
+	{
+		name:      "Successful query creation - configured filter parameter",
+		paginator: styleGuidePaginator,
+		query:     "q=" + url.QueryEscape("name.equals=ammar OR age.lessthan=18"),
+		got: &pagination.Query{
+			Search: &pagination.Search{
+				SQL:        "((name = ?) OR (age < ?))",
+				Parameters: []interface{}{"ammar", "18"},
+			},
+			Paginator: styleGuidePaginator,
+		},
+		err: nil,
+	},
+	{
+		name:      "Successful query creation - default parameter names are not search parameters",
+		paginator: styleGuidePaginator,
+		query:     "limit=abc&order_by=name&name__equals=ammar",
+		got:       &pagination.Query{Paginator: styleGuidePaginator},
+		err:       nil,
+	},
+	{
+		name:      "Successful query creation - nil paginator",
+		paginator: nil,
+		query:     "page=1&limit=5&name__equals=ammar",
+		got: &pagination.Query{
+			Page:  1,
+			Limit: 5,
+			Search: &pagination.Search{
+				SQL:        "((name = ?))",
+				Parameters: []interface{}{"ammar"},
+			},
+		},
+		err: nil,
+	},
+	{
+		name:      "A failed query creation - invalid configured limit",
+		paginator: styleGuidePaginator,
+		query:     "page=1&page_size=0",
+		got:       nil,
+		err:       &pagination.ValidationError{Parameter: "page_size", Value: "0", Err: pagination.ErrInvalidLimit},
+	},
+	{
+		name:      "A failed query creation - invalid configured search operator",
+		paginator: styleGuidePaginator,
+		query:     "name.equals=ammar&age.lessthan=18&match=xor",
+		got:       nil,
+		err:       &pagination.ValidationError{Parameter: "match", Value: "xor", Err: pagination.ErrInvalidSearchOperator},
+	},
+	{
+		name:      "A failed query creation - invalid configured filter",
+		paginator: styleGuidePaginator,
+		query:     "q=name__equals%3Dammar",
+		got:       nil,
+		err:       &pagination.ValidationError{Parameter: "q", Value: "name__equals=ammar", Err: pagination.ErrInvalidFilter, Message: "Filter is invalid near 'name__equals=ammar'"},
+	},
+	{
+		name:      "Successful query creation - limit above a clamped maximum limit",
+		paginator: clampingPaginator,
+		query:     "page=1&limit=1000000",
+		got:       &pagination.Query{Page: 1, Limit: 1000000, Paginator: clampingPaginator},
+		err:       nil,
+	},
+	{
+		name:      "A failed query creation - limit above a rejected maximum limit",
+		paginator: rejectingPaginator,
+		query:     "page=1&limit=1000000",
+		got:       nil,
+		err:       &pagination.ValidationError{Parameter: "limit", Value: "1000000", Err: pagination.ErrLimitTooLarge},
+	},
+	{
+		name:      "Successful query creation - limit equal to a rejected maximum limit",
+		paginator: rejectingPaginator,
+		query:     "page=1&limit=100",
+		got:       &pagination.Query{Page: 1, Limit: 100, Paginator: rejectingPaginator},
+		err:       nil,
+	},
+}
+
+// TestPaginatorNewQuery tests the paginator NewQuery method with configured parameter names.
+func TestPaginatorNewQuery(t *testing.T) {
+	t.Log("Paginator NewQuery")
+
+	// Check each test case
+	for _, testcase := range paginatorQueryDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := testcase.paginator.NewQuery(query)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check query
+		if !reflect.DeepEqual(testcase.got, got) {
+			t.Errorf("Expected query to be %v but got %v", testcase.got, got)
+		}
+	}
+}
+
+// TestPaginatorNewPage tests the paginator NewPage and NewLinks methods with configured parameter names.
+func TestPaginatorNewPage(t *testing.T) {
+	t.Log("Paginator NewPage")
+
+	reqURL, _ := url.Parse("api.demo.com/v1/users?page=2&page_size=2&sort=name")
+	got, err := styleGuidePaginator.NewPage(reqURL, []*User{{ID: 3, Name: "Ammar"}, {ID: 4, Name: "Fatima"}})
+
+	if err != nil {
+		t.Errorf("Expected error to be %v but got %v", nil, err)
+	}
+
+	want := &pagination.Links{
+		Next:     "api.demo.com/v1/users?page=3&page_size=2&sort=name",
+		Previous: "api.demo.com/v1/users?page=1&page_size=2&sort=name",
+		Self:     "api.demo.com/v1/users?page=2&page_size=2&sort=name",
+	}
+
+	if got == nil || !reflect.DeepEqual(want, got.Links) {
+		t.Errorf("Expected links to be %v but got %v", want, got)
+	}
+}
+
+// paginatorLimitDataProvider provides data for the TestPaginatorGetLimit function.
+var paginatorLimitDataProvider = []struct {
+	name  string
+	query *pagination.Query
+	want  int
+}{
+	{
+		name:  "A limit retrieval with a nil paginator and no limit",
+		query: &pagination.Query{Limit: 0},
+		want:  30,
+	},
+	{
+		name:  "A limit retrieval with a nil paginator and a large limit",
+		query: &pagination.Query{Limit: 1000000},
+		want:  1000000,
+	},
+	{
+		name:  "A limit retrieval with a paginator default limit",
+		query: &pagination.Query{Limit: 0, Paginator: clampingPaginator},
+		want:  20,
+	},
+	{
+		name:  "A limit retrieval with a limit within the paginator maximum limit",
+		query: &pagination.Query{Limit: 50, Paginator: clampingPaginator},
+		want:  50,
+	},
+	{
+		name:  "A limit retrieval with a limit above the paginator maximum limit",
+		query: &pagination.Query{Limit: 1000000, Paginator: clampingPaginator},
+		want:  100,
+	},
+	{
+		name:  "A limit retrieval with a paginator maximum limit and no default limit",
+		query: &pagination.Query{Limit: 0, Paginator: &pagination.Paginator{MaxLimit: 10}},
+		want:  10,
+	},
+}
+
+// TestPaginatorGetLimit tests the paginator GetLimit method with configured limits.
+func TestPaginatorGetLimit(t *testing.T) {
+	t.Log("GetLimit with configured limits")
+
+	// Check each test case
+	for _, testcase := range paginatorLimitDataProvider {
+		t.Log(testcase.name)
+
+		got := testcase.query.GetLimit()
+
+		// Check response
+		if testcase.want != got {
+			t.Errorf("Expected response to be %d but got %d", testcase.want, got)
+		}
+	}
+}
+
+// TestPaginatorNewLinks tests the paginator NewLinks method with a clamped maximum limit.
+func TestPaginatorNewLinks(t *testing.T) {
+	t.Log("Paginator NewLinks with a clamped maximum limit")
+
+	reqURL, _ := url.Parse("api.demo.com/v1/users?page=2&limit=1000000")
+	got := clampingPaginator.NewLinks(reqURL, 100)
+
+	want := &pagination.Links{
+		Next:     "api.demo.com/v1/users?limit=100&page=3",
+		Previous: "api.demo.com/v1/users?limit=100&page=1",
+		Self:     "api.demo.com/v1/users?page=2&limit=1000000",
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected links to be %v but got %v", want, got)
+	}
+}
+
+// TestPaginatorNewLinksDefaultLimit tests the paginator NewLinks method with a default limit and no limit url parameter.
+func TestPaginatorNewLinksDefaultLimit(t *testing.T) {
+	t.Log("Paginator NewLinks with a default limit")
+
+	reqURL, _ := url.Parse("api.demo.com/v1/users?page=1")
+	got := (&pagination.Paginator{DefaultLimit: 2}).NewLinks(reqURL, 2)
+
+	want := &pagination.Links{
+		Next: "api.demo.com/v1/users?page=2",
+		Self: "api.demo.com/v1/users?page=1",
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected links to be %v but got %v", want, got)
+	}
+}
+
+// lookAheadPaginator is a paginator fetching one more record than the limit.
+var lookAheadPaginator = &pagination.Paginator{LookAhead: true}
+
+// TestGetFetchLimit tests the paginator GetFetchLimit method.
+func TestGetFetchLimit(t *testing.T) {
+	t.Log("GetFetchLimit")
+
+	if got := (&pagination.Query{Limit: 5}).GetFetchLimit(); got != 5 {
+		t.Errorf("Expected response to be %d but got %d", 5, got)
+	}
+
+	if got := (&pagination.Query{Limit: 5, Paginator: lookAheadPaginator}).GetFetchLimit(); got != 6 {
+		t.Errorf("Expected response to be %d but got %d", 6, got)
+	}
+
+	if got := (&pagination.Query{Paginator: lookAheadPaginator}).GetFetchLimit(); got != 31 {
+		t.Errorf("Expected response to be %d but got %d", 31, got)
+	}
+}
+
+// lookAheadPageDataProvider provides data for the TestLookAheadPage function.
+var lookAheadPageDataProvider = []struct {
+	name    string
+	url     string
+	results []*User
+	want    *pagination.Page
+}{
+	{
+		name:    "Successful page creation - exactly full last page",
+		url:     "api.demo.com/v1/users?page=2&limit=2",
+		results: []*User{{ID: 3}, {ID: 4}},
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Previous: "api.demo.com/v1/users?limit=2&page=1",
+				Self:     "api.demo.com/v1/users?page=2&limit=2",
+			},
+			Count:       2,
+			HasNext:     boolPointer(false),
+			HasPrevious: boolPointer(true),
+			Results:     []*User{{ID: 3}, {ID: 4}},
+		},
+	},
+	{
+		name:    "Successful page creation - page with an extra result",
+		url:     "api.demo.com/v1/users?page=1&limit=2",
+		results: []*User{{ID: 1}, {ID: 2}, {ID: 3}},
+		want: &pagination.Page{
+			Links: &pagination.Links{
+				Next: "api.demo.com/v1/users?limit=2&page=2",
+				Self: "api.demo.com/v1/users?page=1&limit=2",
+			},
+			Count:       2,
+			HasNext:     boolPointer(true),
+			HasPrevious: boolPointer(false),
+			Results:     []*User{{ID: 1}, {ID: 2}},
+		},
+	},
+}
+
+// TestLookAheadPage tests the paginator NewPage method with a paginator looking ahead.
+func TestLookAheadPage(t *testing.T) {
+	t.Log("NewPage looking ahead")
+
+	// Check each test case
+	for _, testcase := range lookAheadPageDataProvider {
+		t.Log(testcase.name)
+
+		reqURL, _ := url.Parse(testcase.url)
+		got, err := lookAheadPaginator.NewPage(reqURL, testcase.results)
+
+		// Check error
+		if err != nil {
+			t.Errorf("Expected error to be %v but got %v", nil, err)
+		}
+
+		// Check page
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected page to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// TestLookAheadKeysetPage tests the paginator NewKeysetPage method with a paginator looking ahead.
+func TestLookAheadKeysetPage(t *testing.T) {
+	t.Log("NewKeysetPage looking ahead")
+
+	key := func(row interface{}) []string {
+		return []string{strconv.FormatUint(row.(*User).ID, 10)}
+	}
+
+	// Results before a position are fetched in reverse order, the extra result is the furthest one
+	reqURL, _ := url.Parse("api.demo.com/v1/users?before=5&limit=2&order_by=id")
+	got, err := lookAheadPaginator.NewKeysetPage(reqURL, []*User{{ID: 4}, {ID: 3}, {ID: 2}}, key)
+
+	want := &pagination.Page{
+		Links: &pagination.Links{
+			Next:     "api.demo.com/v1/users?after=4&limit=2&order_by=id",
+			Previous: "api.demo.com/v1/users?before=3&limit=2&order_by=id",
+			Self:     "api.demo.com/v1/users?before=5&limit=2&order_by=id",
+		},
+		Count:       2,
+		HasNext:     boolPointer(true),
+		HasPrevious: boolPointer(true),
+		Results:     []*User{{ID: 3}, {ID: 4}},
+	}
+
+	if err != nil || !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected page to be %v but got %v (%v)", want, got, err)
+	}
+
+	// The first page has no previous results
+	reqURL, _ = url.Parse("api.demo.com/v1/users?before=3&limit=2&order_by=id")
+	got, _ = lookAheadPaginator.NewKeysetPage(reqURL, []*User{{ID: 2}, {ID: 1}}, key)
+
+	if got == nil || *got.HasPrevious || !*got.HasNext {
+		t.Errorf("Expected page to only have a next link but got %v", got)
+	}
+}
+
+// TestLookAheadPageJSON tests the json encoding of a page of a paginator looking ahead.
+func TestLookAheadPageJSON(t *testing.T) {
+	t.Log("NewPage looking ahead encoded as json")
+
+	reqURL, _ := url.Parse("api.demo.com/v1/users?page=1&limit=2")
+	page, _ := lookAheadPaginator.NewPage(reqURL, []int{1})
+	got, _ := json.Marshal(page)
+
+	want := `{"_links":{"next":"","previous":"","self":"api.demo.com/v1/users?page=1\u0026limit=2"},"count":1,"has_next":false,"has_previous":false,"results":[1]}`
+	if string(got) != want {
+		t.Errorf("Expected json to be %s but got %s", want, got)
+	}
+
+	// Pages of a paginator not looking ahead have no has_next nor has_previous
+	page, _ = pagination.NewPage(reqURL, []int{1})
+	got, _ = json.Marshal(page)
+
+	want = `{"_links":{"next":"","previous":"","self":"api.demo.com/v1/users?page=1\u0026limit=2"},"count":1,"results":[1]}`
+	if string(got) != want {
+		t.Errorf("Expected json to be %s but got %s", want, got)
+	}
+}
@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemType is the type URI of the problem details of pagination validation errors.
+var ProblemType = "https://github.com/yohgo/pagination#invalid-params"
+
+// Problem is a pagination problem details structure as described by RFC 7807.
+type Problem struct {
+	Type          string          `json:"type"`
+	Title         string          `json:"title"`
+	Status        int             `json:"status"`
+	Detail        string          `json:"detail,omitempty"`
+	InvalidParams []*InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam is a pagination problem details entry describing an invalid url parameter.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem creates the problem details of an error returned by ValidateQuery, NewQuery, NewSearch or NewPage.
+// Returns a 400 bad request problem with an invalid parameter entry per validation error, even when wrapped.
+// Returns a 500 internal server error problem without details for any other error.
+// Returns nil if there is no error.
+func NewProblem(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	var errs ValidationErrors
+	var validationErr *ValidationError
+
+	if !errors.As(err, &errs) {
+		if !errors.As(err, &validationErr) {
+			return &Problem{Type: "about:blank", Title: http.StatusText(http.StatusInternalServerError), Status: http.StatusInternalServerError}
+		}
+		errs = ValidationErrors{validationErr}
+	}
+
+	problem := &Problem{
+		Type:   ProblemType,
+		Title:  "Your request parameters didn't validate",
+		Status: http.StatusBadRequest,
+		Detail: errs.Error(),
+	}
+
+	for _, err := range errs {
+		problem.InvalidParams = append(problem.InvalidParams, &InvalidParam{Name: err.Parameter, Value: err.Value, Reason: err.Error()})
+	}
+
+	return problem
+}
+
+// ServeHTTP writes the problem details as an application/problem+json response with the problem status.
+func (problem *Problem) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// WriteProblem writes the problem details of an error as an application/problem+json response.
+// Returns false without writing anything if there is no error.
+func WriteProblem(w http.ResponseWriter, err error) bool {
+	problem := NewProblem(err)
+	if problem == nil {
+		return false
+	}
+
+	problem.ServeHTTP(w, nil)
+
+	return true
+}
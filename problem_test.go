@@ -0,0 +1,137 @@
+package pagination_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// newProblemDataProvider provides data for the TestNewProblem function.
+var newProblemDataProvider = []struct {
+	name string
+	err  error
+	want *pagination.Problem
+}{
+	{
+		name: "No problem creation - no error",
+		err:  nil,
+		want: nil,
+	},
+	{
+		name: "Successful problem creation - validation error",
+		err:  &pagination.ValidationError{Parameter: "limit", Value: "abc", Err: pagination.ErrInvalidLimit},
+		want: &pagination.Problem{
+			Type:   pagination.ProblemType,
+			Title:  "Your request parameters didn't validate",
+			Status: 400,
+			Detail: "Limit is invalid",
+			InvalidParams: []*pagination.InvalidParam{
+				{Name: "limit", Value: "abc", Reason: "Limit is invalid"},
+			},
+		},
+	},
+	{
+		name: "Successful problem creation - validation errors",
+		err: pagination.ValidationErrors{
+			{Parameter: "page", Value: "0", Err: pagination.ErrInvalidPage},
+			{Parameter: "age__equals", Value: "abc", Err: pagination.ErrInvalidValue, Message: "Value 'abc' of 'age' is not a valid int"},
+		},
+		want: &pagination.Problem{
+			Type:   pagination.ProblemType,
+			Title:  "Your request parameters didn't validate",
+			Status: 400,
+			Detail: "Page is invalid; Value 'abc' of 'age' is not a valid int",
+			InvalidParams: []*pagination.InvalidParam{
+				{Name: "page", Value: "0", Reason: "Page is invalid"},
+				{Name: "age__equals", Value: "abc", Reason: "Value 'abc' of 'age' is not a valid int"},
+			},
+		},
+	},
+	{
+		name: "Successful problem creation - wrapped validation error",
+		err:  fmt.Errorf("list users: %w", &pagination.ValidationError{Parameter: "limit", Value: "abc", Err: pagination.ErrInvalidLimit}),
+		want: &pagination.Problem{
+			Type:   pagination.ProblemType,
+			Title:  "Your request parameters didn't validate",
+			Status: 400,
+			Detail: "Limit is invalid",
+			InvalidParams: []*pagination.InvalidParam{
+				{Name: "limit", Value: "abc", Reason: "Limit is invalid"},
+			},
+		},
+	},
+	{
+		name: "Successful problem creation - wrapped validation errors",
+		err: fmt.Errorf("list users: %w", pagination.ValidationErrors{
+			{Parameter: "page", Value: "0", Err: pagination.ErrInvalidPage},
+			{Parameter: "limit", Value: "abc", Err: pagination.ErrInvalidLimit},
+		}),
+		want: &pagination.Problem{
+			Type:   pagination.ProblemType,
+			Title:  "Your request parameters didn't validate",
+			Status: 400,
+			Detail: "Page is invalid; Limit is invalid",
+			InvalidParams: []*pagination.InvalidParam{
+				{Name: "page", Value: "0", Reason: "Page is invalid"},
+				{Name: "limit", Value: "abc", Reason: "Limit is invalid"},
+			},
+		},
+	},
+	{
+		name: "Successful problem creation - internal error",
+		err:  errors.New("The provided collection is not a slice"),
+		want: &pagination.Problem{Type: "about:blank", Title: "Internal Server Error", Status: 500},
+	},
+}
+
+// TestNewProblem tests the paginator NewProblem method.
+func TestNewProblem(t *testing.T) {
+	t.Log("NewProblem")
+
+	// Check each test case
+	for _, testcase := range newProblemDataProvider {
+		t.Log(testcase.name)
+
+		got := pagination.NewProblem(testcase.err)
+
+		// Check problem
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected problem to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// TestWriteProblem tests the paginator WriteProblem method.
+func TestWriteProblem(t *testing.T) {
+	t.Log("WriteProblem")
+
+	query, _ := url.ParseQuery("page=1&limit=abc")
+	_, err := pagination.NewQuery(query)
+
+	recorder := httptest.NewRecorder()
+	if !pagination.WriteProblem(recorder, err) {
+		t.Errorf("Expected a problem to be written")
+	}
+
+	if recorder.Code != 400 {
+		t.Errorf("Expected status to be %d but got %d", 400, recorder.Code)
+	}
+
+	if recorder.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected content type to be %q but got %q", "application/problem+json", recorder.Header().Get("Content-Type"))
+	}
+
+	want := `{"type":"` + pagination.ProblemType + `","title":"Your request parameters didn't validate","status":400,"detail":"Limit is invalid","invalid-params":[{"name":"limit","value":"abc","reason":"Limit is invalid"}]}` + "\n"
+	if recorder.Body.String() != want {
+		t.Errorf("Expected body to be %q but got %q", want, recorder.Body.String())
+	}
+
+	if pagination.WriteProblem(httptest.NewRecorder(), nil) {
+		t.Errorf("Expected no problem to be written")
+	}
+}
@@ -1,104 +1,270 @@
 package pagination
 
 import (
-	"errors"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // Query is a pagination query structure.
 type Query struct {
-	Page    int
-	Limit   int
-	OrderBy string
-	Order   string
-	Search  *Search
+	Page      int
+	Limit     int
+	Offset    int
+	Ranged    bool
+	OrderBy   string
+	Order     string
+	Sort      []SortTerm
+	After     []string
+	Before    []string
+	Search    *Search
+	Schema    *Schema
+	Paginator *Paginator
 }
 
 // NewQuery creates a new pagination query.
-// Returns a validation error if query creation was not successful.
+// The optional schema restricts and maps the fields that can be searched and sorted.
+// The after and before keyset positions, or the position of a cursor signed with the schema cursor key, are added to the query search.
+// The query search and order are rendered for the schema dialect, with search conditions ordered by parameter name.
+// Returns a validation error if query creation was not successful, or all of the validation errors when the schema collects errors.
 // Returns a pagination query if page creation was successful.
-func NewQuery(query url.Values) (*Query, error) {
-	if err := ValidateQuery(query); err != nil {
+func NewQuery(query url.Values, schema ...*Schema) (*Query, error) {
+	return (*Paginator)(nil).NewQuery(query, schema...)
+}
+
+// NewQuery creates a new pagination query from the url parameters named by the paginator.
+func (paginator *Paginator) NewQuery(query url.Values, schema ...*Schema) (*Query, error) {
+	return paginator.newQuery(query, getParamOrder(query, ""), schema)
+}
+
+// NewQueryFromRawQuery creates a new pagination query from a raw url query.
+// Returns a query with search conditions in their order of appearance in the raw query.
+// Returns an error if the raw query cannot be parsed, or any of the NewQuery errors.
+func NewQueryFromRawQuery(rawQuery string, schema ...*Schema) (*Query, error) {
+	return (*Paginator)(nil).NewQueryFromRawQuery(rawQuery, schema...)
+}
+
+// NewQueryFromRawQuery creates a new pagination query from a raw url query with the url parameters named by the paginator.
+func (paginator *Paginator) NewQueryFromRawQuery(rawQuery string, schema ...*Schema) (*Query, error) {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
 		return nil, err
 	}
-	// Converts we validated before so we can ignore errors
-	page, _ := strconv.Atoi(query.Get("page"))
-	limit, _ := strconv.Atoi(query.Get("limit"))
-	search, err := NewSearch(query)
 
-	if err != nil {
+	return paginator.newQuery(query, getParamOrder(query, rawQuery), schema)
+}
+
+// newQuery creates a new pagination query with search conditions following the order of the parameter names.
+func (paginator *Paginator) newQuery(query url.Values, order []string, schema []*Schema) (*Query, error) {
+	errs := paginator.validateQuery(query, getSchema(schema))
+	search, err := paginator.newSearch(query, order, getSchema(schema))
+	errs.add(err, "", "")
+
+	if err := errs.get(getSchema(schema)); err != nil {
 		return nil, err
 	}
+	// Converts we validated before so we can ignore errors
+	page, _ := strconv.Atoi(query.Get(paginator.getParam("page")))
+	limit, _ := strconv.Atoi(query.Get(paginator.getParam("limit")))
+	orderBy, direction := query.Get(paginator.getParam("order_by")), query.Get(paginator.getParam("order"))
+
+	result := &Query{
+		Page:      page,
+		Limit:     limit,
+		OrderBy:   orderBy,
+		Order:     direction,
+		Sort:      ParseSort(orderBy, direction),
+		After:     query[paginator.getParam("after")],
+		Before:    query[paginator.getParam("before")],
+		Schema:    getSchema(schema),
+		Paginator: paginator,
+	}
+	// Converts we validated before so we can ignore errors
+	if cursor, _ := paginator.getCursor(result.Schema, query); cursor != nil && cursor.Backwards {
+		result.Before = cursor.Position
+	} else if cursor != nil {
+		result.After = cursor.Position
+	}
+	result.Search = joinSearches(search, result.getKeysetSearch()).bind(result.Schema.GetDialect())
 
-	return &Query{
-			Page:    page,
-			Limit:   limit,
-			OrderBy: query.Get("order_by"),
-			Order:   query.Get("order"),
-			Search:  search,
-		},
-		nil
+	return result, nil
 }
 
 // ValidateQuery validates a collection of url parameters that form a query.
 // Returns a page is invalid error if the page is less than 1 or not an integer.
 // Returns a limit is invalid error if the limit is less than 1 or not an integer.
-// Returns a order by is invalid error if the order by is not a string.
+// Returns a limit is too large error if the limit is above the maximum limit of a paginator rejecting overflows.
+// Returns a order by is invalid error if an order by field is not a sortable field of the optional schema.
 // Returns a order is invalid error if the order is either "asc", or "desc" or empty.
-func ValidateQuery(query url.Values) error {
-	page, err := strconv.Atoi(query.Get("page"))
+// Returns an after or before is invalid error if a keyset position does not have a value per order by field.
+// Returns a cursor error if the cursor is malformed, expired or was issued for other filters.
+// Returns a cursor is malformed error if the cursor position does not have a value per order by field.
+// Returns a cursor is required error if an after or before position is given to a schema with a cursor key.
+// Returns an invalid value error if a keyset position value is not valid for the type of its sort field.
+// Returns a *ValidationError for the first invalid parameter, or ValidationErrors for all of them when the schema collects errors.
+func ValidateQuery(query url.Values, schema ...*Schema) error {
+	return (*Paginator)(nil).ValidateQuery(query, schema...)
+}
+
+// ValidateQuery validates a collection of url parameters named by the paginator that form a query.
+func (paginator *Paginator) ValidateQuery(query url.Values, schema ...*Schema) error {
+	return paginator.validateQuery(query, getSchema(schema)).get(getSchema(schema))
+}
+
+// validateQuery returns the validation errors of a collection of url parameters that form a query.
+func (paginator *Paginator) validateQuery(query url.Values, schema *Schema) ValidationErrors {
+	var errs ValidationErrors
+
+	pageParam, limitParam := paginator.getParam("page"), paginator.getParam("limit")
+	orderByParam, orderParam := paginator.getParam("order_by"), paginator.getParam("order")
+	afterParam, beforeParam := paginator.getParam("after"), paginator.getParam("before")
+	cursorParam := paginator.getParam("cursor")
+
+	page, err := strconv.Atoi(query.Get(pageParam))
 
-	if query.Get("page") != "" && (err != nil || page <= 0) {
-		return errors.New("Page is invalid")
+	if query.Get(pageParam) != "" && (err != nil || page <= 0) {
+		errs = append(errs, &ValidationError{Parameter: pageParam, Value: query.Get(pageParam), Err: ErrInvalidPage})
 	}
 
-	if query.Get("limit") != "" && query.Get("page") == "" {
-		return errors.New("Page is missing")
+	isKeyset := len(query[afterParam]) != 0 || len(query[beforeParam]) != 0
+	hasCursor := len(query[cursorParam]) != 0
+
+	if query.Get(limitParam) != "" && query.Get(pageParam) == "" && !isKeyset && !hasCursor {
+		errs = append(errs, &ValidationError{Parameter: pageParam, Err: ErrMissingPage})
 	}
 
-	limit, err := strconv.Atoi(query.Get("limit"))
+	if query.Get(pageParam) != "" && isKeyset {
+		errs = append(errs, &ValidationError{Parameter: pageParam, Value: query.Get(pageParam), Err: ErrPageWithPosition})
+	}
 
-	if query.Get("limit") != "" && (err != nil || limit <= 0) {
-		return errors.New("Limit is invalid")
+	if len(query[afterParam]) != 0 && len(query[beforeParam]) != 0 {
+		errs = append(errs, &ValidationError{Parameter: afterParam, Value: strings.Join(query[afterParam], ","), Err: ErrAfterWithBefore})
 	}
 
-	if query.Get("order") != "" && query.Get("order_by") == "" {
-		return errors.New("Order by is missing")
+	// A schema with a cursor key only accepts signed positions
+	for _, param := range []string{afterParam, beforeParam} {
+		if len(query[param]) != 0 && schema != nil && len(schema.CursorKey) != 0 {
+			errs = append(errs, &ValidationError{Parameter: param, Value: strings.Join(query[param], ","), Err: ErrCursorRequired})
+		}
 	}
 
-	if query.Get("order") != "" && query.Get("order") != "asc" && query.Get("order") != "desc" {
-		return errors.New("Order is invalid")
+	if hasCursor && (query.Get(pageParam) != "" || isKeyset) {
+		errs = append(errs, &ValidationError{Parameter: cursorParam, Value: query.Get(cursorParam), Err: ErrCursorWithPosition})
 	}
 
-	return nil
+	cursor, err := paginator.getCursor(schema, query)
+	if err != nil {
+		errs = append(errs, &ValidationError{Parameter: cursorParam, Value: query.Get(cursorParam), Err: err})
+	}
+
+	limit, err := strconv.Atoi(query.Get(limitParam))
+
+	if query.Get(limitParam) != "" && (err != nil || limit <= 0) {
+		errs = append(errs, &ValidationError{Parameter: limitParam, Value: query.Get(limitParam), Err: ErrInvalidLimit})
+	}
+
+	if paginator != nil && paginator.Overflow == RejectOverflow && paginator.MaxLimit > 0 && limit > paginator.MaxLimit {
+		errs = append(errs, &ValidationError{Parameter: limitParam, Value: query.Get(limitParam), Err: ErrLimitTooLarge})
+	}
+
+	terms := ParseSort(query.Get(orderByParam), query.Get(orderParam))
+
+	for _, term := range terms {
+		if _, ok := schema.GetSortColumn(term.Field); term.Field == "" || !ok {
+			errs = append(errs, &ValidationError{Parameter: orderByParam, Value: term.Field, Err: ErrInvalidOrderBy})
+		}
+	}
+
+	if query.Get(orderParam) != "" && query.Get(orderByParam) == "" {
+		errs = append(errs, &ValidationError{Parameter: orderByParam, Err: ErrMissingOrderBy})
+	}
+
+	if query.Get(orderParam) != "" && query.Get(orderParam) != "asc" && query.Get(orderParam) != "desc" {
+		errs = append(errs, &ValidationError{Parameter: orderParam, Value: query.Get(orderParam), Err: ErrInvalidOrder})
+	}
+
+	// A keyset position has a value per sort term, or a single one for the default sort
+	keys := len(terms)
+	if keys == 0 {
+		keys = 1
+	}
+
+	if len(query[afterParam]) != 0 && len(query[afterParam]) != keys {
+		errs = append(errs, &ValidationError{Parameter: afterParam, Value: strings.Join(query[afterParam], ","), Err: ErrInvalidAfter})
+	}
+
+	if len(query[beforeParam]) != 0 && len(query[beforeParam]) != keys {
+		errs = append(errs, &ValidationError{Parameter: beforeParam, Value: strings.Join(query[beforeParam], ","), Err: ErrInvalidBefore})
+	}
+
+	if cursor != nil && len(cursor.Position) != keys {
+		errs = append(errs, &ValidationError{Parameter: cursorParam, Value: query.Get(cursorParam), Err: ErrCursorMalformed})
+	}
+
+	positions := map[string][]string{afterParam: query[afterParam], beforeParam: query[beforeParam]}
+	if cursor != nil {
+		positions[cursorParam] = cursor.Position
+	}
+
+	// The values of a keyset position are parsed for the types of their sort fields
+	for _, param := range []string{afterParam, beforeParam, cursorParam} {
+		if len(positions[param]) != len(terms) {
+			continue
+		}
+
+		for i, term := range terms {
+			_, err := schema.parsePosition(param, term.Field, positions[param][i])
+			errs.add(err, param, positions[param][i])
+		}
+	}
+
+	return errs
 }
 
 // GetOrder returns a sensible order by string to use when querying data from a datastore.
-// Returns a default query order by of created_at when not requesting a particular order by.
+// Returns a default query order by of the schema default sort when not requesting a particular order by.
 // Returns a default query order of ascending when not requesting a particular order.
+// Returns a quoted order by column when the query has a schema.
+// Returns a reversed order when paging backwards with a before position, the results then need to be reversed.
 func (query *Query) GetOrder() string {
-	orderBy := map[bool]string{true: "created_at", false: query.OrderBy}[query.OrderBy == ""]
-	order := map[bool]string{true: "asc", false: "desc"}[query.Order != "desc"]
+	var order []string
+
+	for _, term := range query.getSortColumns() {
+		desc := term.desc != (len(query.Before) != 0)
+		order = append(order, term.column+" "+map[bool]string{true: "desc", false: "asc"}[desc])
+	}
 
-	return orderBy + " " + order
+	return strings.Join(order, ", ")
 }
 
 // GetLimit returns a sensible limit to use when querying data from a datastore.
-// Returns a default query limit when requesting for less than 1 record.
+// Returns the paginator default limit, or 30, when requesting for less than 1 record.
+// Returns the paginator maximum limit when requesting for more records.
 // Returns a set query limit when requesting for a number of records within bounds.
 func (query *Query) GetLimit() int {
-	if query.Limit < 1 {
-		return 30
+	return query.Paginator.getLimit(query.Limit)
+}
+
+// GetFetchLimit returns the number of records to fetch from a datastore.
+// Returns one more record than the limit when the paginator looks ahead, so that the page can tell whether there is a next page.
+// Returns the limit otherwise.
+func (query *Query) GetFetchLimit() int {
+	if query.Paginator != nil && query.Paginator.LookAhead {
+		return query.GetLimit() + 1
 	}
 
-	return query.Limit
+	return query.GetLimit()
 }
 
 // GetOffset returns a sensible offset to use when querying data from a datastore.
 // Returns a default query offset when requesting for less than the second page.
 // Returns a determined query offset when requesting for more than the second page
+// Returns the query offset of a range request, or any query offset greater than 0.
 func (query *Query) GetOffset() int {
+	if query.Ranged || query.Offset > 0 {
+		return query.Offset
+	}
+
 	if query.Page < 2 {
 		return 0
 	}
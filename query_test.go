@@ -1,7 +1,6 @@
 package pagination_test
 
 import (
-	"errors"
 	"net/url"
 	"reflect"
 	"testing"
@@ -9,19 +8,32 @@ import (
 	"github.com/yohgo/pagination"
 )
 
+// usersSchema is a schema used to test queries.
+var usersSchema = &pagination.Schema{
+	Fields: map[string]pagination.Field{
+		"name": {Column: "users.first_name"},
+	},
+	Sorts: map[string]string{
+		"name":    "users.first_name",
+		"surname": "users.last_name",
+	},
+	DefaultSort: "users.id",
+}
+
 // newQueryDataProvider provides data for the TestNewQuery function.
 var newQueryDataProvider = []struct {
-	name  string
-	query string
-	count int
-	got   *pagination.Query
-	err   error
+	name   string
+	query  string
+	schema *pagination.Schema
+	count  int
+	got    *pagination.Query
+	err    error
 }{
 	{
 		name:  "Query creation fails due to an invalid url query",
 		query: "page=invalid_page&limit=invalid_limit&order_by=&order=invalid_order",
 		got:   nil,
-		err:   errors.New("Page is invalid"),
+		err:   &pagination.ValidationError{Parameter: "page", Value: "invalid_page", Err: pagination.ErrInvalidPage},
 	},
 	{
 		name:  "Successful Query creation - no paging, no ordering",
@@ -43,6 +55,7 @@ var newQueryDataProvider = []struct {
 			Limit:   0,
 			OrderBy: "name",
 			Order:   "asc",
+			Sort:    []pagination.SortTerm{{Field: "name"}},
 			Search:  nil,
 		},
 		err: nil,
@@ -55,6 +68,7 @@ var newQueryDataProvider = []struct {
 			Limit:   3,
 			OrderBy: "name",
 			Order:   "asc",
+			Sort:    []pagination.SortTerm{{Field: "name"}},
 			Search:  nil,
 		},
 		err: nil,
@@ -67,6 +81,7 @@ var newQueryDataProvider = []struct {
 			Limit:   3,
 			OrderBy: "name",
 			Order:   "asc",
+			Sort:    []pagination.SortTerm{{Field: "name"}},
 			Search:  nil,
 		},
 		err: nil,
@@ -79,6 +94,7 @@ var newQueryDataProvider = []struct {
 			Limit:   3,
 			OrderBy: "surname",
 			Order:   "desc",
+			Sort:    []pagination.SortTerm{{Field: "surname", Desc: true}},
 			Search:  nil,
 		},
 		err: nil,
@@ -91,6 +107,7 @@ var newQueryDataProvider = []struct {
 			Limit:   3,
 			OrderBy: "surname",
 			Order:   "desc",
+			Sort:    []pagination.SortTerm{{Field: "surname", Desc: true}},
 			Search: &pagination.Search{
 				SQL:        "((name = ?))",
 				Parameters: []interface{}{"ammar"},
@@ -102,7 +119,80 @@ var newQueryDataProvider = []struct {
 		name:  "A failed Query creation - missing search operator",
 		query: "page=3&limit=3&order_by=surname&order=desc&name__equals=ammar&age__lessthan=18",
 		got:   nil,
-		err:   errors.New("Search operator is missing"),
+		err:   &pagination.ValidationError{Parameter: "searchOperator", Err: pagination.ErrMissingSearchOperator},
+	},
+	{
+		name:   "Successful Query creation - with schema",
+		query:  "page=1&limit=3&order_by=name&order=desc",
+		schema: usersSchema,
+		got: &pagination.Query{
+			Page:    1,
+			Limit:   3,
+			OrderBy: "name",
+			Order:   "desc",
+			Sort:    []pagination.SortTerm{{Field: "name", Desc: true}},
+			Search:  nil,
+			Schema:  usersSchema,
+		},
+		err: nil,
+	},
+	{
+		name:   "A failed Query creation - order by not sortable",
+		query:  "page=1&limit=3&order_by=password&order=desc",
+		schema: usersSchema,
+		got:    nil,
+		err:    &pagination.ValidationError{Parameter: "order_by", Value: "password", Err: pagination.ErrInvalidOrderBy},
+	},
+	{
+		name:  "Successful Query creation - after a keyset position",
+		query: "after=ammar&limit=3&order_by=name&age__greaterthan=18",
+		got: &pagination.Query{
+			Page:    0,
+			Limit:   3,
+			OrderBy: "name",
+			Sort:    []pagination.SortTerm{{Field: "name"}},
+			After:   []string{"ammar"},
+			Search: &pagination.Search{
+				SQL:        "(((age > ?)) AND ((name > ?)))",
+				Parameters: []interface{}{"18", "ammar"},
+			},
+		},
+		err: nil,
+	},
+	{
+		name:   "Successful Query creation - before a keyset position",
+		query:  "before=ammar&limit=3&order_by=name&order=desc",
+		schema: usersSchema,
+		got: &pagination.Query{
+			Page:    0,
+			Limit:   3,
+			OrderBy: "name",
+			Order:   "desc",
+			Sort:    []pagination.SortTerm{{Field: "name", Desc: true}},
+			Before:  []string{"ammar"},
+			Search: &pagination.Search{
+				SQL:        "((`users`.`first_name` > ?))",
+				Parameters: []interface{}{"ammar"},
+			},
+			Schema: usersSchema,
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful Query creation - after a multiple field keyset position",
+		query: "after=open&after=3&limit=3&order_by=status,-priority",
+		got: &pagination.Query{
+			Page:    0,
+			Limit:   3,
+			OrderBy: "status,-priority",
+			Sort:    []pagination.SortTerm{{Field: "status"}, {Field: "priority", Desc: true}},
+			After:   []string{"open", "3"},
+			Search: &pagination.Search{
+				SQL:        "((status > ?) OR (status = ? AND priority < ?))",
+				Parameters: []interface{}{"open", "open", "3"},
+			},
+		},
+		err: nil,
 	},
 }
 
@@ -114,7 +204,7 @@ func TestNewQuery(t *testing.T) {
 		t.Log(testcase.name)
 
 		query, _ := url.ParseQuery(testcase.query)
-		want, err := pagination.NewQuery(query)
+		want, err := pagination.NewQuery(query, testcase.schema)
 
 		// Check error
 		if !reflect.DeepEqual(testcase.err, err) {
@@ -123,16 +213,42 @@ func TestNewQuery(t *testing.T) {
 
 		// Check query
 		if !reflect.DeepEqual(testcase.got, want) {
-			t.Errorf("Expected query to be %q but got %q", testcase.got, want)
+			t.Errorf("Expected query to be %v but got %v", testcase.got, want)
 		}
 	}
 }
 
+// TestNewQueryFromRawQuery tests the paginator NewQueryFromRawQuery method.
+func TestNewQueryFromRawQuery(t *testing.T) {
+	t.Log("NewQueryFromRawQuery")
+
+	got, err := pagination.NewQueryFromRawQuery("page=1&limit=3&name__equals=ammar&age__lessthan=18&searchOperator=OR")
+	want := &pagination.Query{
+		Page:  1,
+		Limit: 3,
+		Search: &pagination.Search{
+			SQL:        "((name = ?) OR (age < ?))",
+			Parameters: []interface{}{"ammar", "18"},
+		},
+	}
+
+	// Check query
+	if err != nil || !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected query to be %v but got %v (%v)", want, got, err)
+	}
+
+	// Check error
+	if _, err := pagination.NewQueryFromRawQuery("page=1;limit=3"); err == nil {
+		t.Errorf("Expected an error for an invalid raw query")
+	}
+}
+
 // validateQueryDataProvider provides data for the TestValidateQuery function.
 var validateQueryDataProvider = []struct {
-	name  string
-	query string
-	err   error
+	name   string
+	query  string
+	schema *pagination.Schema
+	err    error
 }{
 	{
 		name:  "A successful validation",
@@ -147,47 +263,115 @@ var validateQueryDataProvider = []struct {
 	{
 		name:  "Validation fails due to an invalid page",
 		query: "page=invalid page&limit=invalid limit&order_by=&order=",
-		err:   errors.New("Page is invalid"),
+		err:   &pagination.ValidationError{Parameter: "page", Value: "invalid page", Err: pagination.ErrInvalidPage},
 	},
 	{
 		name:  "Validation fails due to a zero page",
 		query: "page=0&limit=invalid limit&order_by=&order=",
-		err:   errors.New("Page is invalid"),
+		err:   &pagination.ValidationError{Parameter: "page", Value: "0", Err: pagination.ErrInvalidPage},
 	},
 	{
 		name:  "Validation fails due to a negative page",
 		query: "page=-3&limit=invalid limit&order_by=&order=",
-		err:   errors.New("Page is invalid"),
+		err:   &pagination.ValidationError{Parameter: "page", Value: "-3", Err: pagination.ErrInvalidPage},
 	},
 	{
 		name:  "Validation fails due to a missing page",
 		query: "page=&limit=2&order_by=&order=",
-		err:   errors.New("Page is missing"),
+		err:   &pagination.ValidationError{Parameter: "page", Err: pagination.ErrMissingPage},
 	},
 	{
 		name:  "Validation fails due to an invalid limit",
 		query: "page=1&limit=invalid limit&order_by=&order=",
-		err:   errors.New("Limit is invalid"),
+		err:   &pagination.ValidationError{Parameter: "limit", Value: "invalid limit", Err: pagination.ErrInvalidLimit},
 	},
 	{
 		name:  "Validation fails due to an zero limit",
 		query: "page=1&limit=0&order_by=&order=",
-		err:   errors.New("Limit is invalid"),
+		err:   &pagination.ValidationError{Parameter: "limit", Value: "0", Err: pagination.ErrInvalidLimit},
 	},
 	{
 		name:  "Validation fails due to a negative limit",
 		query: "page=1&limit=-4&order_by=&order=",
-		err:   errors.New("Limit is invalid"),
+		err:   &pagination.ValidationError{Parameter: "limit", Value: "-4", Err: pagination.ErrInvalidLimit},
 	},
 	{
 		name:  "Validation fails due to a missing order by",
 		query: "page=1&limit=5&order_by=&order=desc",
-		err:   errors.New("Order by is missing"),
+		err:   &pagination.ValidationError{Parameter: "order_by", Err: pagination.ErrMissingOrderBy},
 	},
 	{
 		name:  "Validation fails due to an invalid order",
 		query: "page=1&limit=5&order_by=name&order=invalid order",
-		err:   errors.New("Order is invalid"),
+		err:   &pagination.ValidationError{Parameter: "order", Value: "invalid order", Err: pagination.ErrInvalidOrder},
+	},
+	{
+		name:  "Validation fails due to an injected order by",
+		query: "page=1&limit=5&order_by=name%3BDROP%20TABLE%20users&order=asc",
+		err:   &pagination.ValidationError{Parameter: "order_by", Value: "name;DROP TABLE users", Err: pagination.ErrInvalidOrderBy},
+	},
+	{
+		name:   "A successful validation with a sortable field",
+		query:  "page=1&limit=5&order_by=surname&order=asc",
+		schema: usersSchema,
+		err:    nil,
+	},
+	{
+		name:   "Validation fails due to an order by that is not sortable",
+		query:  "page=1&limit=5&order_by=age&order=asc",
+		schema: usersSchema,
+		err:    &pagination.ValidationError{Parameter: "order_by", Value: "age", Err: pagination.ErrInvalidOrderBy},
+	},
+	{
+		name:  "A successful validation with an after position",
+		query: "after=5&limit=5&order_by=id",
+		err:   nil,
+	},
+	{
+		name:  "Validation fails due to a page with a keyset position",
+		query: "page=2&limit=5&after=5",
+		err:   &pagination.ValidationError{Parameter: "page", Value: "2", Err: pagination.ErrPageWithPosition},
+	},
+	{
+		name:  "Validation fails due to combined keyset positions",
+		query: "after=5&before=10",
+		err:   &pagination.ValidationError{Parameter: "after", Value: "5", Err: pagination.ErrAfterWithBefore},
+	},
+	{
+		name:  "Validation fails due to an invalid after position",
+		query: "after=5&after=6",
+		err:   &pagination.ValidationError{Parameter: "after", Value: "5,6", Err: pagination.ErrInvalidAfter},
+	},
+	{
+		name:  "Validation fails due to an invalid before position",
+		query: "before=5&before=6",
+		err:   &pagination.ValidationError{Parameter: "before", Value: "5,6", Err: pagination.ErrInvalidBefore},
+	},
+	{
+		name:  "A successful validation with multiple order by fields",
+		query: "page=1&limit=5&order_by=status,-priority,id",
+		err:   nil,
+	},
+	{
+		name:  "A successful validation with a multiple field after position",
+		query: "after=open&after=3&after=7&limit=5&order_by=status,-priority,id",
+		err:   nil,
+	},
+	{
+		name:  "Validation fails due to an after position missing a field",
+		query: "after=open&after=3&limit=5&order_by=status,-priority,id",
+		err:   &pagination.ValidationError{Parameter: "after", Value: "open,3", Err: pagination.ErrInvalidAfter},
+	},
+	{
+		name:  "Validation fails due to an empty order by field",
+		query: "page=1&limit=5&order_by=status,,id",
+		err:   &pagination.ValidationError{Parameter: "order_by", Value: "", Err: pagination.ErrInvalidOrderBy},
+	},
+	{
+		name:   "Validation fails due to an order by field that is not sortable",
+		query:  "page=1&limit=5&order_by=name,-age",
+		schema: usersSchema,
+		err:    &pagination.ValidationError{Parameter: "order_by", Value: "age", Err: pagination.ErrInvalidOrderBy},
 	},
 }
 
@@ -199,7 +383,7 @@ func TestValidateQuery(t *testing.T) {
 		t.Log(testcase.name)
 
 		query, _ := url.ParseQuery(testcase.query)
-		err := pagination.ValidateQuery(query)
+		err := pagination.ValidateQuery(query, testcase.schema)
 
 		// Check error
 		if !reflect.DeepEqual(testcase.err, err) {
@@ -247,6 +431,78 @@ var getOrderDataProvider = []struct {
 		query: &pagination.Query{},
 		want:  "created_at asc",
 	},
+	{
+		name: "An order retrieval with a schema",
+		query: &pagination.Query{
+			OrderBy: "surname",
+			Order:   "desc",
+			Schema:  usersSchema,
+		},
+		want: "`users`.`last_name` desc",
+	},
+	{
+		name: "An order retrieval with a schema having no order by",
+		query: &pagination.Query{
+			Schema: usersSchema,
+		},
+		want: "`users`.`id` asc",
+	},
+	{
+		name: "An order retrieval with a schema having no default sort",
+		query: &pagination.Query{
+			Schema: &pagination.Schema{},
+		},
+		want: "`created_at` asc",
+	},
+	{
+		name: "An order retrieval with an unsortable order by",
+		query: &pagination.Query{
+			OrderBy: "name;DROP TABLE users",
+		},
+		want: "created_at asc",
+	},
+	{
+		name: "An order retrieval with a before position",
+		query: &pagination.Query{
+			OrderBy: "name",
+			Order:   "asc",
+			Before:  []string{"ammar"},
+		},
+		want: "name desc",
+	},
+	{
+		name: "An order retrieval with multiple sort terms",
+		query: &pagination.Query{
+			Sort: []pagination.SortTerm{
+				{Field: "status"},
+				{Field: "priority", Desc: true},
+				{Field: "id"},
+			},
+		},
+		want: "status asc, priority desc, id asc",
+	},
+	{
+		name: "An order retrieval with multiple sort terms and a schema",
+		query: &pagination.Query{
+			Sort: []pagination.SortTerm{
+				{Field: "surname", Desc: true},
+				{Field: "name"},
+			},
+			Schema: usersSchema,
+		},
+		want: "`users`.`last_name` desc, `users`.`first_name` asc",
+	},
+	{
+		name: "An order retrieval with multiple sort terms and a before position",
+		query: &pagination.Query{
+			Sort: []pagination.SortTerm{
+				{Field: "status"},
+				{Field: "priority", Desc: true},
+			},
+			Before: []string{"open", "3"},
+		},
+		want: "status desc, priority asc",
+	},
 }
 
 // TestGetOrder tests the paginator GetOrder method.
@@ -0,0 +1,149 @@
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// rangePattern matches a single items range such as "items=0-24" or "items=25-".
+var rangePattern = regexp.MustCompile(`^items=([0-9]+)-([0-9]*)$`)
+
+// NewQueryFromRequest creates a new pagination query from a request.
+// The offset and limit come from a Range header such as "Range: items=0-24" when present, and the rest of the query from the url parameters.
+// Returns a ranged query when the offset and limit come from a Range header.
+// Falls back to the page and limit url parameters when the request has no items Range header.
+// Returns a range is invalid error if the Range header is not a single items range, or is combined with a page, after, before or cursor.
+// Returns any of the NewQuery errors.
+func NewQueryFromRequest(req *http.Request, schema ...*Schema) (*Query, error) {
+	return (*Paginator)(nil).NewQueryFromRequest(req, schema...)
+}
+
+// NewQueryFromRequest creates a new pagination query from a request with the url parameters named by the paginator.
+func (paginator *Paginator) NewQueryFromRequest(req *http.Request, schema ...*Schema) (*Query, error) {
+	header := req.Header.Get("Range")
+	query := req.URL.Query()
+	// Other range units are ignored
+	if !strings.HasPrefix(header, "items=") {
+		return paginator.NewQuery(query, schema...)
+	}
+
+	offset, limit, err := paginator.parseRange(header, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ranged := url.Values{}
+	for param, values := range query {
+		ranged[param] = values
+	}
+	ranged.Del(paginator.getParam("limit"))
+
+	result, err := paginator.NewQuery(ranged, schema...)
+	if err != nil {
+		return nil, err
+	}
+	result.Offset, result.Limit, result.Ranged = offset, limit, true
+
+	return result, nil
+}
+
+// parseRange returns the offset and limit of an items Range header.
+// Returns a range is invalid error if the header is not a single items range or is combined with a page or keyset position.
+// Returns a limit is too large error if the range is above the maximum limit of a paginator rejecting overflows.
+func (paginator *Paginator) parseRange(header string, query url.Values) (offset, limit int, err error) {
+	if query.Get(paginator.getParam("page")) != "" || paginator.isKeyset(query) {
+		return 0, 0, &ValidationError{
+			Parameter: "Range",
+			Value:     header,
+			Err:       ErrInvalidRange,
+			Message:   "Range cannot be combined with page, after, before or cursor",
+		}
+	}
+
+	bounds := rangePattern.FindStringSubmatch(header)
+	if bounds == nil {
+		return 0, 0, &ValidationError{Parameter: "Range", Value: header, Err: ErrInvalidRange}
+	}
+
+	offset, err = strconv.Atoi(bounds[1])
+	if err != nil {
+		return 0, 0, &ValidationError{Parameter: "Range", Value: header, Err: ErrInvalidRange}
+	}
+
+	// An open range uses the default limit
+	if bounds[2] == "" {
+		return offset, 0, nil
+	}
+
+	last, err := strconv.Atoi(bounds[2])
+	if err != nil || last < offset {
+		return 0, 0, &ValidationError{Parameter: "Range", Value: header, Err: ErrInvalidRange}
+	}
+
+	limit = last - offset + 1
+	if paginator != nil && paginator.Overflow == RejectOverflow && paginator.MaxLimit > 0 && limit > paginator.MaxLimit {
+		return 0, 0, &ValidationError{Parameter: "Range", Value: header, Err: ErrLimitTooLarge}
+	}
+
+	return offset, limit, nil
+}
+
+// NewRangePage creates a new pagination page of a query, with a known total number of results or a negative total when unknown.
+// The page of a ranged query is trimmed to the range limit, and its page size and total pages follow the range limit.
+// The page of a ranged query only has a self link, since a range is not a numbered page.
+// Returns the page of NewPageWithTotal with the query schema for a query that is not ranged.
+// Returns an error if the result is not a slice.
+func NewRangePage(reqURL *url.URL, query *Query, result interface{}, total int) (*Page, error) {
+	paginator := query.Paginator
+	if !query.Ranged {
+		return paginator.newPage(reqURL, result, total, query.Schema)
+	}
+	// Check if result is a slice
+	aType := reflect.ValueOf(result)
+	if aType.Kind() != reflect.Slice {
+		return nil, errors.New("The provided collection is not a slice")
+	}
+
+	limit := query.GetLimit()
+	fetched := aType.Len()
+	aType = paginator.trimResults(aType, limit)
+
+	page := &Page{Links: &Links{Self: reqURL.String()}, Count: aType.Len(), Results: aType.Interface()}
+	if paginator != nil && paginator.LookAhead {
+		page.HasNext, page.HasPrevious = boolPointer(paginator.hasMore(fetched, limit)), boolPointer(query.GetOffset() > 0)
+	}
+
+	if total >= 0 {
+		totalPages := getTotalPages(total, limit)
+		page.Total, page.TotalPages, page.PageSize = &total, &totalPages, limit
+	}
+
+	return page, nil
+}
+
+// WriteRange writes the results of a page of a query as a bare json array, with the pagination in the response headers.
+// Sets an "Accept-Ranges: items" header along with the headers of WriteResults.
+// Writes a 206 partial content status, or a 200 ok status when the page holds all of the results.
+// Writes a 416 range not satisfiable status without results when the query offset is past the known total.
+// Returns an error if the results cannot be encoded.
+func WriteRange(w http.ResponseWriter, query *Query, page *Page) error {
+	w.Header().Set("Accept-Ranges", "items")
+
+	if page.Total != nil && page.Count == 0 && query.GetOffset() > 0 && query.GetOffset() >= *page.Total {
+		w.Header().Set("Content-Range", "items */"+strconv.Itoa(*page.Total))
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+
+		return nil
+	}
+
+	if page.Total != nil && query.GetOffset() == 0 && page.Count == *page.Total {
+		return writeResults(w, query, page, http.StatusOK)
+	}
+
+	return writeResults(w, query, page, http.StatusPartialContent)
+}
@@ -0,0 +1,294 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// newQueryFromRequestDataProvider provides data for the TestNewQueryFromRequest function.
+var newQueryFromRequestDataProvider = []struct {
+	name      string
+	url       string
+	header    string
+	paginator *pagination.Paginator
+	got       *pagination.Query
+	err       error
+}{
+	{
+		name:   "Successful query creation - items range",
+		url:    "api.demo.com/v1/users?order_by=name&name__equals=ammar",
+		header: "items=25-49",
+		got: &pagination.Query{
+			Limit:   25,
+			Offset:  25,
+			Ranged:  true,
+			OrderBy: "name",
+			Sort:    []pagination.SortTerm{{Field: "name"}},
+			Search: &pagination.Search{
+				SQL:        "((name = ?))",
+				Parameters: []interface{}{"ammar"},
+			},
+		},
+		err: nil,
+	},
+	{
+		name:   "Successful query creation - open items range",
+		url:    "api.demo.com/v1/users?limit=10",
+		header: "items=10-",
+		got:    &pagination.Query{Offset: 10, Ranged: true},
+		err:    nil,
+	},
+	{
+		name:   "Successful query creation - no range",
+		url:    "api.demo.com/v1/users?page=2&limit=10",
+		header: "",
+		got:    &pagination.Query{Page: 2, Limit: 10},
+		err:    nil,
+	},
+	{
+		name:   "Successful query creation - other range unit",
+		url:    "api.demo.com/v1/users?page=2&limit=10",
+		header: "bytes=0-499",
+		got:    &pagination.Query{Page: 2, Limit: 10},
+		err:    nil,
+	},
+	{
+		name:      "Successful query creation - clamped items range",
+		url:       "api.demo.com/v1/users",
+		header:    "items=0-999",
+		paginator: clampingPaginator,
+		got:       &pagination.Query{Limit: 1000, Ranged: true, Paginator: clampingPaginator},
+		err:       nil,
+	},
+	{
+		name:      "A failed query creation - rejected items range",
+		url:       "api.demo.com/v1/users",
+		header:    "items=0-999",
+		paginator: rejectingPaginator,
+		got:       nil,
+		err:       &pagination.ValidationError{Parameter: "Range", Value: "items=0-999", Err: pagination.ErrLimitTooLarge},
+	},
+	{
+		name:   "A failed query creation - reversed items range",
+		url:    "api.demo.com/v1/users",
+		header: "items=24-0",
+		got:    nil,
+		err:    &pagination.ValidationError{Parameter: "Range", Value: "items=24-0", Err: pagination.ErrInvalidRange},
+	},
+	{
+		name:   "A failed query creation - multiple items ranges",
+		url:    "api.demo.com/v1/users",
+		header: "items=0-24,50-74",
+		got:    nil,
+		err:    &pagination.ValidationError{Parameter: "Range", Value: "items=0-24,50-74", Err: pagination.ErrInvalidRange},
+	},
+	{
+		name:   "A failed query creation - suffix items range",
+		url:    "api.demo.com/v1/users",
+		header: "items=-25",
+		got:    nil,
+		err:    &pagination.ValidationError{Parameter: "Range", Value: "items=-25", Err: pagination.ErrInvalidRange},
+	},
+	{
+		name:   "A failed query creation - items range with a page",
+		url:    "api.demo.com/v1/users?page=2",
+		header: "items=0-24",
+		got:    nil,
+		err: &pagination.ValidationError{
+			Parameter: "Range",
+			Value:     "items=0-24",
+			Err:       pagination.ErrInvalidRange,
+			Message:   "Range cannot be combined with page, after, before or cursor",
+		},
+	},
+	{
+		name:   "A failed query creation - invalid url parameters",
+		url:    "api.demo.com/v1/users?order=up",
+		header: "items=0-24",
+		got:    nil,
+		err:    &pagination.ValidationError{Parameter: "order_by", Err: pagination.ErrMissingOrderBy},
+	},
+}
+
+// TestNewQueryFromRequest tests the paginator NewQueryFromRequest method.
+func TestNewQueryFromRequest(t *testing.T) {
+	t.Log("NewQueryFromRequest")
+
+	// Check each test case
+	for _, testcase := range newQueryFromRequestDataProvider {
+		t.Log(testcase.name)
+
+		req := httptest.NewRequest("GET", "http://"+testcase.url, nil)
+		if testcase.header != "" {
+			req.Header.Set("Range", testcase.header)
+		}
+		got, err := testcase.paginator.NewQueryFromRequest(req)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check query
+		if !reflect.DeepEqual(testcase.got, got) {
+			t.Errorf("Expected query to be %v but got %v", testcase.got, got)
+		}
+	}
+}
+
+// TestRangeOffset tests the paginator GetOffset method with a range request offset.
+func TestRangeOffset(t *testing.T) {
+	t.Log("GetOffset with a range request offset")
+
+	if got := (&pagination.Query{Page: 3, Limit: 5, Offset: 7}).GetOffset(); got != 7 {
+		t.Errorf("Expected response to be %d but got %d", 7, got)
+	}
+}
+
+// writeRangeDataProvider provides data for the TestWriteRange function.
+var writeRangeDataProvider = []struct {
+	name    string
+	header  string
+	results []*User
+	total   int
+	status  int
+	want    http.Header
+}{
+	{
+		name:    "Successful range writing - partial content",
+		header:  "items=0-1",
+		results: []*User{{ID: 1}, {ID: 2}},
+		total:   5,
+		status:  http.StatusPartialContent,
+		want: http.Header{
+			"Accept-Ranges": {"items"},
+			"Content-Range": {"items 0-1/5"},
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users>; rel="self"`},
+			"X-Per-Page":    {"2"},
+			"X-Total-Count": {"5"},
+		},
+	},
+	{
+		name:    "Successful range writing - partial content of an unknown total",
+		header:  "items=2-3",
+		results: []*User{{ID: 3}, {ID: 4}},
+		total:   -1,
+		status:  http.StatusPartialContent,
+		want: http.Header{
+			"Accept-Ranges": {"items"},
+			"Content-Range": {"items 2-3/*"},
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users>; rel="self"`},
+			"X-Per-Page":    {"2"},
+		},
+	},
+	{
+		name:    "Successful range writing - all results",
+		header:  "items=0-9",
+		results: []*User{{ID: 1}, {ID: 2}},
+		total:   2,
+		status:  http.StatusOK,
+		want: http.Header{
+			"Accept-Ranges": {"items"},
+			"Content-Range": {"items 0-1/2"},
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users>; rel="self"`},
+			"X-Per-Page":    {"10"},
+			"X-Total-Count": {"2"},
+		},
+	},
+	{
+		name:    "A failed range writing - range past the total",
+		header:  "items=10-19",
+		results: []*User{},
+		total:   5,
+		status:  http.StatusRequestedRangeNotSatisfiable,
+		want: http.Header{
+			"Accept-Ranges": {"items"},
+			"Content-Range": {"items */5"},
+		},
+	},
+}
+
+// TestWriteRange tests the paginator WriteRange method.
+func TestWriteRange(t *testing.T) {
+	t.Log("WriteRange")
+
+	// Check each test case
+	for _, testcase := range writeRangeDataProvider {
+		t.Log(testcase.name)
+
+		req := httptest.NewRequest("GET", "http://api.demo.com/v1/users", nil)
+		req.Header.Set("Range", testcase.header)
+		query, _ := pagination.NewQueryFromRequest(req)
+		reqURL, _ := url.Parse("api.demo.com/v1/users")
+		page, _ := pagination.NewRangePage(reqURL, query, testcase.results, testcase.total)
+
+		recorder := httptest.NewRecorder()
+		if err := pagination.WriteRange(recorder, query, page); err != nil {
+			t.Errorf("Expected error to be %v but got %v", nil, err)
+		}
+
+		// Check status
+		if recorder.Code != testcase.status {
+			t.Errorf("Expected status to be %d but got %d", testcase.status, recorder.Code)
+		}
+
+		// Check headers
+		if !reflect.DeepEqual(testcase.want, recorder.Header()) {
+			t.Errorf("Expected headers to be %v but got %v", testcase.want, recorder.Header())
+		}
+	}
+}
+
+// TestLookAheadRange tests the paginator NewRangePage and WriteRange methods with a paginator looking ahead.
+func TestLookAheadRange(t *testing.T) {
+	t.Log("NewRangePage and WriteRange looking ahead")
+
+	req := httptest.NewRequest("GET", "http://api.demo.com/v1/users", nil)
+	req.Header.Set("Range", "items=0-9")
+	query, _ := lookAheadPaginator.NewQueryFromRequest(req)
+
+	// Check fetch limit
+	if got := query.GetFetchLimit(); got != 11 {
+		t.Errorf("Expected fetch limit to be %d but got %d", 11, got)
+	}
+
+	var results []*User
+	for id := uint64(1); id <= 11; id++ {
+		results = append(results, &User{ID: id})
+	}
+
+	reqURL, _ := url.Parse("api.demo.com/v1/users")
+	got, err := pagination.NewRangePage(reqURL, query, results, 100)
+
+	want := &pagination.Page{
+		Links:       &pagination.Links{Self: "api.demo.com/v1/users"},
+		Count:       10,
+		Total:       intPointer(100),
+		TotalPages:  intPointer(10),
+		PageSize:    10,
+		HasNext:     boolPointer(true),
+		HasPrevious: boolPointer(false),
+		Results:     results[:10],
+	}
+
+	// Check page
+	if err != nil || !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected page to be %v but got %v (%v)", want, got, err)
+	}
+
+	recorder := httptest.NewRecorder()
+	pagination.WriteRange(recorder, query, got)
+
+	// Check content range
+	if contentRange := recorder.Header().Get("Content-Range"); contentRange != "items 0-9/100" {
+		t.Errorf("Expected Content-Range header to be %s but got %s", "items 0-9/100", contentRange)
+	}
+}
@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// SetHeaders sets the pagination headers of a page of a query on a response header.
+// Sets the Link header of the page links, and the X-Total-Count header when the total number of results is known.
+// Sets the X-Per-Page header, and the X-Page and Content-Range headers, such as "items 0-24/319", for a query that is not keyset paginated.
+// Sets no X-Page header, and only the self relation of the Link header, for a ranged query.
+// Uses an unknown "*" Content-Range total when the total number of results is not known.
+func (page *Page) SetHeaders(header http.Header, query *Query) {
+	links := page.Links
+	// A range request is not paged through page numbers
+	if query.Ranged && links != nil {
+		links = &Links{Self: links.Self}
+	}
+
+	if link := links.GetLinkHeader(); link != "" {
+		header.Set("Link", link)
+	}
+
+	total := "*"
+	if page.Total != nil {
+		total = strconv.Itoa(*page.Total)
+		header.Set("X-Total-Count", total)
+	}
+
+	header.Set("X-Per-Page", strconv.Itoa(query.GetLimit()))
+
+	// A keyset position is not a numbered page
+	if len(query.After) != 0 || len(query.Before) != 0 {
+		return
+	}
+
+	// A range request is not a numbered page
+	if !query.Ranged {
+		currentPage := query.Page
+		if currentPage < 1 {
+			currentPage = 1
+		}
+		header.Set("X-Page", strconv.Itoa(currentPage))
+	}
+
+	if page.Count == 0 {
+		header.Set("Content-Range", "items */"+total)
+	} else {
+		start := query.GetOffset()
+		header.Set("Content-Range", "items "+strconv.Itoa(start)+"-"+strconv.Itoa(start+page.Count-1)+"/"+total)
+	}
+}
+
+// WriteResults writes the results of a page of a query as a bare json array, with the pagination in the response headers.
+// Returns an error if the page results are not a slice or cannot be encoded.
+func WriteResults(w http.ResponseWriter, query *Query, page *Page) error {
+	return writeResults(w, query, page, http.StatusOK)
+}
+
+// writeResults writes the results of a page of a query as a bare json array with a status.
+func writeResults(w http.ResponseWriter, query *Query, page *Page, status int) error {
+	results := page.Results
+	// Check if results is a slice
+	aType := reflect.ValueOf(results)
+	if aType.Kind() != reflect.Slice {
+		return errors.New("The provided collection is not a slice")
+	}
+
+	if aType.Len() == 0 {
+		results = []interface{}{}
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	page.SetHeaders(w.Header(), query)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+
+	return err
+}
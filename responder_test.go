@@ -0,0 +1,145 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// writeResultsDataProvider provides data for the TestWriteResults function.
+var writeResultsDataProvider = []struct {
+	name    string
+	url     string
+	results []*User
+	total   int
+	header  http.Header
+	body    string
+}{
+	{
+		name:    "Successful results writing - known total",
+		url:     "api.demo.com/v1/users?page=2&limit=2",
+		results: []*User{{ID: 3, Name: "John"}, {ID: 4, Name: "Jill"}},
+		total:   5,
+		header: http.Header{
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users?limit=2&page=3>; rel="next", <api.demo.com/v1/users?limit=2&page=1>; rel="prev", <api.demo.com/v1/users?limit=2&page=1>; rel="first", <api.demo.com/v1/users?limit=2&page=3>; rel="last", <api.demo.com/v1/users?page=2&limit=2>; rel="self"`},
+			"X-Total-Count": {"5"},
+			"X-Page":        {"2"},
+			"X-Per-Page":    {"2"},
+			"Content-Range": {"items 2-3/5"},
+		},
+		body: `[{"ID":3,"Name":"John","Surname":""},{"ID":4,"Name":"Jill","Surname":""}]`,
+	},
+	{
+		name:    "Successful results writing - unknown total",
+		url:     "api.demo.com/v1/users",
+		results: []*User{{ID: 1, Name: "John"}},
+		total:   -1,
+		header: http.Header{
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users>; rel="self"`},
+			"X-Page":        {"1"},
+			"X-Per-Page":    {"30"},
+			"Content-Range": {"items 0-0/*"},
+		},
+		body: `[{"ID":1,"Name":"John","Surname":""}]`,
+	},
+	{
+		name:    "Successful results writing - no results",
+		url:     "api.demo.com/v1/users?page=4&limit=2",
+		results: nil,
+		total:   5,
+		header: http.Header{
+			"Content-Type":  {"application/json"},
+			"Link":          {`<api.demo.com/v1/users?limit=2&page=3>; rel="prev", <api.demo.com/v1/users?limit=2&page=1>; rel="first", <api.demo.com/v1/users?limit=2&page=3>; rel="last", <api.demo.com/v1/users?page=4&limit=2>; rel="self"`},
+			"X-Total-Count": {"5"},
+			"X-Page":        {"4"},
+			"X-Per-Page":    {"2"},
+			"Content-Range": {"items */5"},
+		},
+		body: `[]`,
+	},
+	{
+		name:    "Successful results writing - keyset position",
+		url:     "api.demo.com/v1/users?after=2&limit=2&order_by=id",
+		results: []*User{{ID: 3, Name: "John"}},
+		total:   -1,
+		header: http.Header{
+			"Content-Type": {"application/json"},
+			"Link":         {`<api.demo.com/v1/users?after=2&limit=2&order_by=id>; rel="self"`},
+			"X-Per-Page":   {"2"},
+		},
+		body: `[{"ID":3,"Name":"John","Surname":""}]`,
+	},
+}
+
+// TestWriteResults tests the paginator WriteResults method.
+func TestWriteResults(t *testing.T) {
+	t.Log("WriteResults")
+
+	// Check each test case
+	for _, testcase := range writeResultsDataProvider {
+		t.Log(testcase.name)
+
+		reqURL, _ := url.Parse(testcase.url)
+		query, _ := pagination.NewQuery(reqURL.Query())
+		page, _ := pagination.NewPageWithTotal(reqURL, testcase.results, testcase.total)
+
+		recorder := httptest.NewRecorder()
+		if err := pagination.WriteResults(recorder, query, page); err != nil {
+			t.Errorf("Expected error to be %v but got %v", nil, err)
+		}
+
+		// Check headers
+		if !reflect.DeepEqual(testcase.header, recorder.Header()) {
+			t.Errorf("Expected headers to be %v but got %v", testcase.header, recorder.Header())
+		}
+
+		// Check body
+		if recorder.Body.String() != testcase.body {
+			t.Errorf("Expected body to be %q but got %q", testcase.body, recorder.Body.String())
+		}
+	}
+}
+
+// TestWriteResultsNotASlice tests the paginator WriteResults method with page results that are not a slice.
+func TestWriteResultsNotASlice(t *testing.T) {
+	t.Log("WriteResults with results that are not a slice")
+
+	// Check each page
+	for _, page := range []*pagination.Page{{Links: &pagination.Links{}}, {Links: &pagination.Links{}, Results: &User{ID: 1}}} {
+		recorder := httptest.NewRecorder()
+		err := pagination.WriteResults(recorder, &pagination.Query{}, page)
+
+		// Check error
+		if err == nil || err.Error() != "The provided collection is not a slice" {
+			t.Errorf("Expected error to be %q but got %v", "The provided collection is not a slice", err)
+		}
+
+		// Check body
+		if recorder.Body.Len() != 0 {
+			t.Errorf("Expected body to be empty but got %q", recorder.Body.String())
+		}
+	}
+}
+
+// TestSetHeadersWithoutLinks tests the paginator SetHeaders method with a page without links.
+func TestSetHeadersWithoutLinks(t *testing.T) {
+	t.Log("SetHeaders without links")
+
+	header := http.Header{}
+	(&pagination.Page{Count: 1, Results: []int{1}}).SetHeaders(header, &pagination.Query{})
+
+	want := http.Header{
+		"X-Page":        {"1"},
+		"X-Per-Page":    {"30"},
+		"Content-Range": {"items 0-0/*"},
+	}
+	if !reflect.DeepEqual(want, header) {
+		t.Errorf("Expected headers to be %v but got %v", want, header)
+	}
+}
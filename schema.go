@@ -0,0 +1,125 @@
+package pagination
+
+import (
+	"regexp"
+	"time"
+)
+
+// identifierPattern matches a plain, optionally table qualified, column name.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Schema is a pagination schema structure describing what a resource exposes.
+type Schema struct {
+	Fields        map[string]Field
+	Sorts         map[string]string
+	DefaultSort   string
+	CursorKey     []byte
+	CursorTTL     time.Duration
+	Dialect       Dialect
+	MaxListLength int
+	Operators     map[string]Operator
+	CollectErrors bool
+}
+
+// Field is a pagination schema field structure.
+type Field struct {
+	Column string
+	Type   FieldType
+	Enum   []string
+}
+
+// GetColumn returns the column expression a public field name is mapped to.
+// Returns the field name itself when the schema is nil and the name is a plain column name.
+// Returns false if the field is not declared in the schema or is not a plain column name.
+func (schema *Schema) GetColumn(field string) (string, bool) {
+	if schema == nil && !identifierPattern.MatchString(field) {
+		return "", false
+	}
+
+	if schema == nil {
+		return field, true
+	}
+
+	definition, ok := schema.Fields[field]
+	if !ok {
+		return "", false
+	}
+
+	if definition.Column == "" {
+		return field, true
+	}
+
+	return definition.Column, true
+}
+
+// getField returns the definition of a public field name.
+// Returns an empty definition when the schema is nil or does not declare the field.
+func (schema *Schema) getField(field string) Field {
+	if schema == nil {
+		return Field{}
+	}
+
+	return schema.Fields[field]
+}
+
+// GetSortColumn returns the column a public sort field name is mapped to.
+// Returns the field name itself when the schema is nil and the name is a plain column name.
+// Returns false if the field is not declared as sortable or is not a plain column name.
+func (schema *Schema) GetSortColumn(field string) (string, bool) {
+	if schema == nil && !identifierPattern.MatchString(field) {
+		return "", false
+	}
+
+	if schema == nil {
+		return field, true
+	}
+
+	column, ok := schema.Sorts[field]
+	if !ok {
+		return "", false
+	}
+
+	if column == "" {
+		return field, true
+	}
+
+	return column, true
+}
+
+// GetDefaultSort returns the column to sort by when a query does not request a particular order by.
+func (schema *Schema) GetDefaultSort() string {
+	if schema == nil || schema.DefaultSort == "" {
+		return "created_at"
+	}
+
+	return schema.DefaultSort
+}
+
+// GetDialect returns the dialect the SQL of the schema is rendered for.
+// Returns the MySQL dialect when the schema has no dialect.
+func (schema *Schema) GetDialect() Dialect {
+	if schema == nil || schema.Dialect == nil {
+		return MySQL
+	}
+
+	return schema.Dialect
+}
+
+// GetMaxListLength returns the maximum number of values of a search condition.
+// Returns a default maximum of 100 values when the schema has no maximum list length.
+func (schema *Schema) GetMaxListLength() int {
+	if schema == nil || schema.MaxListLength < 1 {
+		return 100
+	}
+
+	return schema.MaxListLength
+}
+
+// getSchema returns the first schema of an optional schema argument.
+func getSchema(schemas []*Schema) *Schema {
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	return schemas[0]
+}
@@ -0,0 +1,153 @@
+package pagination_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// getColumnDataProvider provides data for the TestGetColumn function.
+var getColumnDataProvider = []struct {
+	name   string
+	schema *pagination.Schema
+	field  string
+	column string
+	ok     bool
+}{
+	{
+		name:   "A column retrieval without a schema",
+		schema: nil,
+		field:  "name",
+		column: "name",
+		ok:     true,
+	},
+	{
+		name:   "A column retrieval without a schema for a qualified column",
+		schema: nil,
+		field:  "users.name",
+		column: "users.name",
+		ok:     true,
+	},
+	{
+		name:   "A failed column retrieval without a schema for an expression",
+		schema: nil,
+		field:  "name) OR (1",
+		column: "",
+		ok:     false,
+	},
+	{
+		name: "A column retrieval with a mapped field",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{"name": {Column: "LOWER(users.name)"}},
+		},
+		field:  "name",
+		column: "LOWER(users.name)",
+		ok:     true,
+	},
+	{
+		name: "A column retrieval with an unmapped field",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{"name": {}},
+		},
+		field:  "name",
+		column: "name",
+		ok:     true,
+	},
+	{
+		name: "A failed column retrieval with an undeclared field",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{"name": {}},
+		},
+		field:  "age",
+		column: "",
+		ok:     false,
+	},
+}
+
+// TestGetColumn tests the paginator GetColumn method.
+func TestGetColumn(t *testing.T) {
+	t.Log("GetColumn")
+	// Check each test case
+	for _, testcase := range getColumnDataProvider {
+		t.Log(testcase.name)
+
+		column, ok := testcase.schema.GetColumn(testcase.field)
+
+		// Check column
+		if !reflect.DeepEqual(testcase.column, column) {
+			t.Errorf("Expected column to be %s but got %s", testcase.column, column)
+		}
+
+		// Check ok
+		if testcase.ok != ok {
+			t.Errorf("Expected ok to be %t but got %t", testcase.ok, ok)
+		}
+	}
+}
+
+// getSortColumnDataProvider provides data for the TestGetSortColumn function.
+var getSortColumnDataProvider = []struct {
+	name   string
+	schema *pagination.Schema
+	field  string
+	column string
+	ok     bool
+}{
+	{
+		name:   "A sort column retrieval without a schema",
+		schema: nil,
+		field:  "name",
+		column: "name",
+		ok:     true,
+	},
+	{
+		name:   "A failed sort column retrieval without a schema for an expression",
+		schema: nil,
+		field:  "name;DROP TABLE users",
+		column: "",
+		ok:     false,
+	},
+	{
+		name:   "A sort column retrieval with a mapped field",
+		schema: &pagination.Schema{Sorts: map[string]string{"name": "users.first_name"}},
+		field:  "name",
+		column: "users.first_name",
+		ok:     true,
+	},
+	{
+		name:   "A sort column retrieval with an unmapped field",
+		schema: &pagination.Schema{Sorts: map[string]string{"name": ""}},
+		field:  "name",
+		column: "name",
+		ok:     true,
+	},
+	{
+		name:   "A failed sort column retrieval with a searchable but unsortable field",
+		schema: &pagination.Schema{Fields: map[string]pagination.Field{"name": {}}},
+		field:  "name",
+		column: "",
+		ok:     false,
+	},
+}
+
+// TestGetSortColumn tests the paginator GetSortColumn method.
+func TestGetSortColumn(t *testing.T) {
+	t.Log("GetSortColumn")
+	// Check each test case
+	for _, testcase := range getSortColumnDataProvider {
+		t.Log(testcase.name)
+
+		column, ok := testcase.schema.GetSortColumn(testcase.field)
+
+		// Check column
+		if !reflect.DeepEqual(testcase.column, column) {
+			t.Errorf("Expected column to be %s but got %s", testcase.column, column)
+		}
+
+		// Check ok
+		if testcase.ok != ok {
+			t.Errorf("Expected ok to be %t but got %t", testcase.ok, ok)
+		}
+	}
+}
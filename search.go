@@ -1,9 +1,9 @@
 package pagination
 
 import (
-	"errors"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -14,97 +14,237 @@ type Search struct {
 }
 
 // NewSearch uses the url parameters to create a search struct.
+// Only fields declared in the optional schema can be searched, and they are mapped to their columns.
+// Without a schema any plain column name can be searched.
+// Returns an unknown search field error if a search condition refers to a field that cannot be searched.
 // Returns an unknown search operation if an unknown search operation was encountered.
+// Returns a search operator is invalid error if the search operator is neither "AND" nor "OR".
 // Returns a search operator is missing error if multiple search condition were provided without a search operation.
 // Returns a cannot find search conditions error if a search operator was provided without having at least two search conditions.
-func NewSearch(query url.Values) (*Search, error) {
+// Returns a filter error if the filter expression cannot be parsed.
+// Returns a search with the placeholders, quoted columns and date functions of the schema dialect.
+// Returns a search with conditions ordered by search parameter name.
+// Returns a *ValidationError for the first invalid parameter, or ValidationErrors for all of them when the schema collects errors.
+func NewSearch(query url.Values, schema ...*Schema) (*Search, error) {
+	return (*Paginator)(nil).NewSearch(query, schema...)
+}
+
+// NewSearch uses the url parameters named by the paginator to create a search struct.
+func (paginator *Paginator) NewSearch(query url.Values, schema ...*Schema) (*Search, error) {
+	search, err := paginator.newSearch(query, getParamOrder(query, ""), getSchema(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	return search.bind(getSchema(schema).GetDialect()), nil
+}
+
+// NewSearchFromRawQuery uses a raw url query to create a search struct.
+// Returns a search with conditions in their order of appearance in the raw query.
+// Returns an error if the raw query cannot be parsed, or any of the NewSearch errors.
+func NewSearchFromRawQuery(rawQuery string, schema ...*Schema) (*Search, error) {
+	return (*Paginator)(nil).NewSearchFromRawQuery(rawQuery, schema...)
+}
+
+// NewSearchFromRawQuery uses a raw url query with the url parameters named by the paginator to create a search struct.
+func (paginator *Paginator) NewSearchFromRawQuery(rawQuery string, schema ...*Schema) (*Search, error) {
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := paginator.newSearch(query, getParamOrder(query, rawQuery), getSchema(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	return search.bind(getSchema(schema).GetDialect()), nil
+}
+
+// newSearch creates a search struct with question mark placeholders.
+// The search conditions follow the order of the parameter names.
+// The flat search conditions and the filter expression are both required when both are provided.
+func (paginator *Paginator) newSearch(query url.Values, order []string, schema *Schema) (*Search, error) {
 	var conditions []string
 	var parameters []interface{}
+	var errs ValidationErrors
+
+	operatorParam := paginator.getParam("searchOperator")
+	operator := strings.ToUpper(query.Get(operatorParam))
+	isValidOperator := operator == "" || operator == "AND" || operator == "OR"
 
-	operator := query.Get("searchOperator")
+	if !isValidOperator {
+		errs = append(errs, &ValidationError{Parameter: operatorParam, Value: query.Get(operatorParam), Err: ErrInvalidSearchOperator})
+	}
+
+	searchPattern := "^(.+" + regexp.QuoteMeta(paginator.getSeparator()) + ".+)$"
 
-	for queryParam, value := range query {
-		if isASearchCondition, _ := regexp.MatchString(`^(.+__.+)$`, queryParam); isASearchCondition && len(value) != 0 {
-			paramComponents := strings.Split(queryParam, "__")
-			condition, parameter := GetSearchComponents(paramComponents[0], paramComponents[1], value[0])
+	for _, queryParam := range order {
+		value := query[queryParam]
+		if isASearchCondition, _ := regexp.MatchString(searchPattern, queryParam); isASearchCondition && len(value) != 0 {
+			condition, conditionParameters, err := paginator.getCondition(schema, queryParam, value)
 
-			if condition == "" || parameter == "" {
-				return nil, errors.New("Unknown search operation '" + paramComponents[1] + "'")
+			if err != nil {
+				errs.add(err, queryParam, strings.Join(value, ","))
+				continue
 			}
 
 			conditions = append(conditions, condition)
-			parameters = append(parameters, parameter)
+			parameters = append(parameters, conditionParameters...)
 		}
 	}
 
 	if len(conditions) > 1 && operator == "" {
-		return nil, errors.New("Search operator is missing")
+		errs = append(errs, &ValidationError{Parameter: operatorParam, Err: ErrMissingSearchOperator})
+	}
+
+	if isValidOperator && operator != "" && len(conditions) < 2 {
+		errs = append(errs, &ValidationError{Parameter: operatorParam, Value: query.Get(operatorParam), Err: ErrMissingSearchConditions})
 	}
 
-	if operator != "" && len(conditions) < 2 {
-		return nil, errors.New("Cannot find search conditions")
+	filter, err := paginator.getFilterSearch(query, schema)
+	errs.add(err, paginator.getParam("filter"), query.Get(paginator.getParam("filter")))
+
+	if err := errs.get(schema); err != nil {
+		return nil, err
 	}
 
 	// No search query parameters were provided in the url
 	if len(conditions) == 0 && len(parameters) == 0 {
-		return nil, nil
+		return filter, nil
 	}
 
-	return &Search{
+	return joinSearches(&Search{
 		SQL:        "(" + strings.Join(conditions, " "+operator+" ") + ")",
 		Parameters: parameters,
-	}, nil
+	}, filter), nil
+}
+
+// getParamOrder returns the names of the url parameters in their order of appearance in the raw query.
+// Returns the names sorted when the raw query is empty, and appends the names missing from the raw query sorted.
+func getParamOrder(query url.Values, rawQuery string) []string {
+	var order []string
+	seen := map[string]bool{}
+
+	for _, pair := range strings.Split(rawQuery, "&") {
+		name, _ := url.QueryUnescape(strings.SplitN(pair, "=", 2)[0])
+		if _, ok := query[name]; ok && !seen[name] {
+			order = append(order, name)
+			seen[name] = true
+		}
+	}
+
+	var missing []string
+	for name := range query {
+		if !seen[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	return append(order, missing...)
+}
+
+// getCondition returns the search condition of a search parameter such as name__equals or name__not__contains.
+// An error of the search operation that is not a validation error is returned as an invalid value error.
+// Returns an unknown search field error if the parameter refers to a field that cannot be searched.
+// Returns an unknown search operation error if the parameter refers to an unknown search operation.
+// Returns a too many values error if the condition has more parameters than the schema maximum list length.
+// Returns a search operation error if the values are not valid for the search operation.
+// The operations are looked up in the schema operators before the registered operators.
+// The column passed to the operation is quoted for the schema dialect when there is a schema.
+func (paginator *Paginator) getCondition(schema *Schema, queryParam string, values []string) (string, []interface{}, error) {
+	paramComponents := strings.Split(queryParam, paginator.getSeparator())
+	column, ok := schema.GetColumn(paramComponents[0])
+
+	if !ok {
+		return "", nil, &ValidationError{
+			Parameter: queryParam,
+			Value:     strings.Join(values, ","),
+			Err:       ErrUnknownField,
+			Message:   "Unknown search field '" + paramComponents[0] + "'",
+		}
+	}
+
+	// Columns of a schema are quoted like its sort columns
+	if schema != nil {
+		column = quoteColumn(schema.GetDialect(), column)
+	}
+
+	// A not modifier negates the operation that follows it
+	operation, isNegated := paramComponents[1], false
+	if operation == "not" && len(paramComponents) > 2 {
+		operation, isNegated = paramComponents[2], true
+	}
+
+	operator, ok := schema.GetOperator(operation)
+
+	if !ok {
+		return "", nil, &ValidationError{
+			Parameter: queryParam,
+			Value:     strings.Join(values, ","),
+			Err:       ErrUnknownOperation,
+			Message:   "Unknown search operation '" + operation + "'",
+		}
+	}
+
+	condition, parameters, err := operator(&Condition{
+		Parameter: queryParam,
+		Field:     paramComponents[0],
+		Column:    column,
+		Type:      schema.getField(paramComponents[0]).Type,
+		Enum:      schema.getField(paramComponents[0]).Enum,
+		Values:    values,
+		Dialect:   schema.GetDialect(),
+	})
+
+	if err != nil {
+		return "", nil, toValidationError(err, queryParam, strings.Join(values, ","))
+	}
+
+	if len(parameters) > schema.GetMaxListLength() {
+		return "", nil, &ValidationError{
+			Parameter: queryParam,
+			Value:     strings.Join(values, ","),
+			Err:       ErrTooManyValues,
+			Message:   "Too many values for '" + queryParam + "'",
+		}
+	}
+
+	if isNegated {
+		condition = "(NOT " + condition + ")"
+	}
+
+	return condition, parameters, nil
 }
 
-// GetSearchComponents is a helper method that returns a search condition.
+// GetSearchComponents is a helper method that returns a MySQL search condition.
+// Returns an empty condition and parameter for operations that do not have a single string parameter.
 func GetSearchComponents(field, operator, value string) (condition, parameter string) {
-	switch operator {
-	case "equals":
-		condition = "(" + field + " = ?)"
-		parameter = value
-	case "notequals":
-		condition = "(" + field + " != ?)"
-		parameter = value
-	case "greaterthan":
-		condition = "(" + field + " > ?)"
-		parameter = value
-	case "lessthan":
-		condition = "(" + field + " < ?)"
-		parameter = value
-	case "gthanorequals":
-		condition = "(" + field + " >= ?)"
-		parameter = value
-	case "lthanorequals":
-		condition = "(" + field + " <= ?)"
-		parameter = value
-	case "startswith":
-		condition = "(" + field + " LIKE ?)"
-		parameter = value + "%"
-	case "endswith":
-		condition = "(" + field + " LIKE ?)"
-		parameter = "%" + value
-	case "contains":
-		condition = "(" + field + " LIKE ?)"
-		parameter = "%" + value + "%"
-	case "after":
-		condition = "(" + field + " > ?)"
-		parameter = value
-	case "before":
-		condition = "(" + field + " < ?)"
-		parameter = value
-	case "year":
-		condition = "(YEAR(" + field + ") = ?)"
-		parameter = value
-	case "month":
-		condition = "(MONTH(" + field + ") = ?)"
-		parameter = value
-	case "day":
-		condition = "(DAY(" + field + ") = ?)"
-		parameter = value
-	default:
-		condition = ""
-		parameter = ""
+	searchOperator, ok := (*Schema)(nil).GetOperator(operator)
+	if !ok {
+		return "", ""
+	}
+
+	condition, parameters, err := searchOperator(&Condition{Field: field, Column: field, Values: []string{value}, Dialect: MySQL})
+
+	if err != nil || len(parameters) != 1 {
+		return "", ""
+	}
+
+	parameter, ok = parameters[0].(string)
+	if !ok {
+		return "", ""
 	}
 
 	return condition, parameter
 }
+
+// bind replaces the question mark placeholders of the search with the dialect placeholders.
+func (search *Search) bind(dialect Dialect) *Search {
+	if search == nil {
+		return nil
+	}
+
+	return &Search{SQL: bindPlaceholders(dialect, search.SQL), Parameters: search.Parameters}
+}
@@ -1,7 +1,6 @@
 package pagination_test
 
 import (
-	"errors"
 	"net/url"
 	"reflect"
 	"testing"
@@ -23,17 +22,22 @@ var newSearchDataProvider = []struct {
 	{
 		name:  "Successful search creation - Missing search operator",
 		query: "name__equals=ammar&type__notequals=admin&age__greaterthan=18",
-		err:   errors.New("Search operator is missing"),
+		err:   &pagination.ValidationError{Parameter: "searchOperator", Err: pagination.ErrMissingSearchOperator},
 	},
 	{
 		name:  "Successful search creation - Unknown search operation",
 		query: "name__equals=ammar&type__notequals=admin&age__unknownoperation=18&searchOperator=AND",
-		err:   errors.New("Unknown search operation 'unknownoperation'"),
+		err:   &pagination.ValidationError{Parameter: "age__unknownoperation", Value: "18", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'unknownoperation'"},
+	},
+	{
+		name:  "A failed search creation - Invalid search operator",
+		query: "name__equals=ammar&age__greaterthan=18&searchOperator=OR%201=1",
+		err:   &pagination.ValidationError{Parameter: "searchOperator", Value: "OR 1=1", Err: pagination.ErrInvalidSearchOperator},
 	},
 	{
 		name:  "Successful search creation - No search conditions",
 		query: "searchOperator=AND",
-		err:   errors.New("Cannot find search conditions"),
+		err:   &pagination.ValidationError{Parameter: "searchOperator", Value: "AND", Err: pagination.ErrMissingSearchConditions},
 	},
 	{
 		name:  "A failed search creation - No search query parameters",
@@ -59,6 +63,433 @@ func TestNewSearch(t *testing.T) {
 	}
 }
 
+// newSchemaSearchDataProvider provides data for the TestNewSearchWithSchema function.
+var newSchemaSearchDataProvider = []struct {
+	name   string
+	query  string
+	schema *pagination.Schema
+	want   *pagination.Search
+	err    error
+}{
+	{
+		name:  "Successful search creation - mapped field",
+		query: "name__equals=ammar",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{
+				"name": {Column: "users.first_name"},
+			},
+		},
+		want: &pagination.Search{
+			SQL:        "((`users`.`first_name` = ?))",
+			Parameters: []interface{}{"ammar"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - field without a column",
+		query: "age__greaterthan=18",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{
+				"age": {},
+			},
+		},
+		want: &pagination.Search{
+			SQL:        "((`age` > ?))",
+			Parameters: []interface{}{"18"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - field not in schema",
+		query: "password__equals=secret",
+		schema: &pagination.Schema{
+			Fields: map[string]pagination.Field{
+				"name": {Column: "users.first_name"},
+			},
+		},
+		want: nil,
+		err:  &pagination.ValidationError{Parameter: "password__equals", Value: "secret", Err: pagination.ErrUnknownField, Message: "Unknown search field 'password'"},
+	},
+	{
+		name:   "A failed search creation - injected field without a schema",
+		query:  "1)%20OR%20(1__equals=1",
+		schema: nil,
+		want:   nil,
+		err:    &pagination.ValidationError{Parameter: "1) OR (1__equals", Value: "1", Err: pagination.ErrUnknownField, Message: "Unknown search field '1) OR (1'"},
+	},
+}
+
+// TestNewSearchWithSchema tests the paginator NewSearch method with a schema.
+func TestNewSearchWithSchema(t *testing.T) {
+	t.Log("NewSearch with schema")
+	// Check each test case
+	for _, testcase := range newSchemaSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query, testcase.schema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// searchOrderDataProvider provides data for the TestSearchOrder function.
+var searchOrderDataProvider = []struct {
+	name  string
+	query string
+	raw   bool
+	want  *pagination.Search
+}{
+	{
+		name:  "A search with conditions ordered by parameter name",
+		query: "type__notequals=admin&name__equals=ammar&age__greaterthan=18&searchOperator=AND",
+		raw:   false,
+		want: &pagination.Search{
+			SQL:        "((age > ?) AND (name = ?) AND (type != ?))",
+			Parameters: []interface{}{"18", "ammar", "admin"},
+		},
+	},
+	{
+		name:  "A search with conditions in their order of appearance",
+		query: "type__notequals=admin&name__equals=ammar&age__greaterthan=18&searchOperator=AND",
+		raw:   true,
+		want: &pagination.Search{
+			SQL:        "((type != ?) AND (name = ?) AND (age > ?))",
+			Parameters: []interface{}{"admin", "ammar", "18"},
+		},
+	},
+	{
+		name:  "A search with escaped parameter names in their order of appearance",
+		query: "type__notequals=admin&%6Eame__equals=ammar&searchOperator=OR&type__notequals=guest",
+		raw:   true,
+		want: &pagination.Search{
+			SQL:        "((type != ?) OR (name = ?))",
+			Parameters: []interface{}{"admin", "ammar"},
+		},
+	},
+}
+
+// TestSearchOrder tests the order of the paginator NewSearch and NewSearchFromRawQuery conditions.
+func TestSearchOrder(t *testing.T) {
+	t.Log("Search order")
+	// Check each test case
+	for _, testcase := range searchOrderDataProvider {
+		t.Log(testcase.name)
+		// Checks several times since url parameters are stored in a map
+		for i := 0; i < 10; i++ {
+			var got *pagination.Search
+			if testcase.raw {
+				got, _ = pagination.NewSearchFromRawQuery(testcase.query)
+			} else {
+				query, _ := url.ParseQuery(testcase.query)
+				got, _ = pagination.NewSearch(query)
+			}
+
+			// Check search
+			if !reflect.DeepEqual(testcase.want, got) {
+				t.Fatalf("Expected search to be %v but got %v", testcase.want, got)
+			}
+		}
+	}
+}
+
+// listSearchDataProvider provides data for the TestListSearch function.
+var listSearchDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation - in with comma separated values",
+		query: "status__in=open,pending",
+		want: &pagination.Search{
+			SQL:        "((`status` IN (?, ?)))",
+			Parameters: []interface{}{"open", "pending"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - in with repeated values",
+		query: "id__in=1&id__in=2,3",
+		want: &pagination.Search{
+			SQL:        "((`id` IN (?, ?, ?)))",
+			Parameters: []interface{}{"1", "2", "3"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - notin with a single value",
+		query: "status__notin=closed&name__equals=ammar&searchOperator=AND",
+		want: &pagination.Search{
+			SQL:        "((`name` = ?) AND (`status` NOT IN (?)))",
+			Parameters: []interface{}{"ammar", "closed"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - too many values",
+		query: "id__in=1,2,3,4",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "id__in", Value: "1,2,3,4", Err: pagination.ErrTooManyValues, Message: "Too many values for 'id__in'"},
+	},
+}
+
+// TestListSearch tests the paginator NewSearch method with list operations.
+func TestListSearch(t *testing.T) {
+	t.Log("NewSearch with list operations")
+	// Check each test case
+	for _, testcase := range listSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query, &pagination.Schema{
+			Fields:        map[string]pagination.Field{"id": {}, "name": {}, "status": {}},
+			MaxListLength: 3,
+		})
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// rangeSearchDataProvider provides data for the TestRangeSearch function.
+var rangeSearchDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation - between dates",
+		query: "created__between=2024-01-01,2024-02-01",
+		want: &pagination.Search{
+			SQL:        "((created BETWEEN ? AND ?))",
+			Parameters: []interface{}{"2024-01-01", "2024-02-01"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - between repeated numeric bounds",
+		query: "age__between=9&age__between=18",
+		want: &pagination.Search{
+			SQL:        "((age BETWEEN ? AND ?))",
+			Parameters: []interface{}{"9", "18"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - between equal strings",
+		query: "name__between=ammar,ammar&age__equals=18&searchOperator=OR",
+		want: &pagination.Search{
+			SQL:        "((age = ?) OR (name BETWEEN ? AND ?))",
+			Parameters: []interface{}{"18", "ammar", "ammar"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - between with a missing bound",
+		query: "created__between=2024-01-01,",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "created__between", Value: "2024-01-01,", Err: pagination.ErrInvalidValue, Message: "Search operation 'between' requires two values"},
+	},
+	{
+		name:  "A failed search creation - between with a single bound",
+		query: "created__between=2024-01-01",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "created__between", Value: "2024-01-01", Err: pagination.ErrInvalidValue, Message: "Search operation 'between' requires two values"},
+	},
+	{
+		name:  "A failed search creation - between unordered dates",
+		query: "created__between=2024-02-01 00:00:00,2024-01-01 00:00:00",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "created__between", Value: "2024-02-01 00:00:00,2024-01-01 00:00:00", Err: pagination.ErrInvalidValue, Message: "Search operation 'between' requires ordered values"},
+	},
+	{
+		name:  "A failed search creation - between unordered numbers",
+		query: "age__between=18,9",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "age__between", Value: "18,9", Err: pagination.ErrInvalidValue, Message: "Search operation 'between' requires ordered values"},
+	},
+}
+
+// TestRangeSearch tests the paginator NewSearch method with range operations.
+func TestRangeSearch(t *testing.T) {
+	t.Log("NewSearch with range operations")
+	// Check each test case
+	for _, testcase := range rangeSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// nullSearchDataProvider provides data for the TestNullSearch function.
+var nullSearchDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation - isnull",
+		query: "deleted_at__isnull=true",
+		want: &pagination.Search{
+			SQL:        "((deleted_at IS NULL))",
+			Parameters: nil,
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - isnull false with another condition",
+		query: "deleted_at__isnull=false&name__equals=ammar&searchOperator=AND",
+		want: &pagination.Search{
+			SQL:        "((deleted_at IS NOT NULL) AND (name = ?))",
+			Parameters: []interface{}{"ammar"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - isnotnull",
+		query: "manager_id__isnotnull=true",
+		want: &pagination.Search{
+			SQL:        "((manager_id IS NOT NULL))",
+			Parameters: nil,
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - equals an empty value",
+		query: "nickname__equals=",
+		want: &pagination.Search{
+			SQL:        "((nickname = ?))",
+			Parameters: []interface{}{""},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - isnull without a boolean",
+		query: "deleted_at__isnull=",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "deleted_at__isnull", Value: "", Err: pagination.ErrInvalidValue, Message: "Search operation 'isnull' requires a true or false value"},
+	},
+}
+
+// TestNullSearch tests the paginator NewSearch method with null operations.
+func TestNullSearch(t *testing.T) {
+	t.Log("NewSearch with null operations")
+	// Check each test case
+	for _, testcase := range nullSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// negatedSearchDataProvider provides data for the TestNegatedSearch function.
+var negatedSearchDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation - not contains",
+		query: "name__not__contains=bot",
+		want: &pagination.Search{
+			SQL:        "((NOT (name LIKE ? ESCAPE '!')))",
+			Parameters: []interface{}{"%bot%"},
+		},
+		err: nil,
+	},
+	{
+		name:  "Successful search creation - not in with another condition",
+		query: "status__not__in=open,pending&name__not__startswith=adm&searchOperator=OR",
+		want: &pagination.Search{
+			SQL:        "((NOT (name LIKE ? ESCAPE '!')) OR (NOT (status IN (?, ?))))",
+			Parameters: []interface{}{"adm%", "open", "pending"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed search creation - not without an operation",
+		query: "name__not=bot",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "name__not", Value: "bot", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'not'"},
+	},
+	{
+		name:  "A failed search creation - not with an unknown operation",
+		query: "name__not__like_a=bot",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "name__not__like_a", Value: "bot", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'like_a'"},
+	},
+	{
+		name:  "A failed search creation - double negation",
+		query: "name__not__not__contains=bot",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "name__not__not__contains", Value: "bot", Err: pagination.ErrUnknownOperation, Message: "Unknown search operation 'not'"},
+	},
+}
+
+// TestNegatedSearch tests the paginator NewSearch method with negated operations.
+func TestNegatedSearch(t *testing.T) {
+	t.Log("NewSearch with negated operations")
+	// Check each test case
+	for _, testcase := range negatedSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %q but got %q", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
 // getSearchComponentsDataProvider provides data for the TestgetSearchComponents function.
 var getSearchComponentsDataProvider = []struct {
 	name      string
@@ -121,7 +552,7 @@ var getSearchComponentsDataProvider = []struct {
 		field:     "name",
 		operator:  "startswith",
 		value:     "am",
-		condition: "(name LIKE ?)",
+		condition: "(name LIKE ? ESCAPE '!')",
 		parameter: "am%",
 	},
 	{
@@ -129,7 +560,7 @@ var getSearchComponentsDataProvider = []struct {
 		field:     "name",
 		operator:  "endswith",
 		value:     "am",
-		condition: "(name LIKE ?)",
+		condition: "(name LIKE ? ESCAPE '!')",
 		parameter: "%am",
 	},
 	{
@@ -137,9 +568,57 @@ var getSearchComponentsDataProvider = []struct {
 		field:     "name",
 		operator:  "contains",
 		value:     "mm",
-		condition: "(name LIKE ?)",
+		condition: "(name LIKE ? ESCAPE '!')",
 		parameter: "%mm%",
 	},
+	{
+		name:      "An search condition retrieval with the contains operation and wildcards",
+		field:     "name",
+		operator:  "contains",
+		value:     "50%_off!",
+		condition: "(name LIKE ? ESCAPE '!')",
+		parameter: "%50!%!_off!!%",
+	},
+	{
+		name:      "An search condition retrieval with the like operation",
+		field:     "name",
+		operator:  "like",
+		value:     "50%_off",
+		condition: "(name LIKE ? ESCAPE '!')",
+		parameter: "50%_off",
+	},
+	{
+		name:      "An search condition retrieval with the iequals operation",
+		field:     "name",
+		operator:  "iequals",
+		value:     "Ammar",
+		condition: "(LOWER(name) = LOWER(?))",
+		parameter: "Ammar",
+	},
+	{
+		name:      "An search condition retrieval with the istartswith operation",
+		field:     "name",
+		operator:  "istartswith",
+		value:     "Am",
+		condition: "(LOWER(name) LIKE LOWER(?) ESCAPE '!')",
+		parameter: "Am%",
+	},
+	{
+		name:      "An search condition retrieval with the iendswith operation",
+		field:     "name",
+		operator:  "iendswith",
+		value:     "Am",
+		condition: "(LOWER(name) LIKE LOWER(?) ESCAPE '!')",
+		parameter: "%Am",
+	},
+	{
+		name:      "An search condition retrieval with the icontains operation",
+		field:     "name",
+		operator:  "icontains",
+		value:     "MM",
+		condition: "(LOWER(name) LIKE LOWER(?) ESCAPE '!')",
+		parameter: "%MM%",
+	},
 	{
 		name:      "An search condition retrieval with the after operation",
 		field:     "created_at",
@@ -180,6 +659,22 @@ var getSearchComponentsDataProvider = []struct {
 		condition: "(DAY(created_at) = ?)",
 		parameter: "11",
 	},
+	{
+		name:      "An search condition retrieval with the in operation",
+		field:     "status",
+		operator:  "in",
+		value:     "open",
+		condition: "(status IN (?))",
+		parameter: "open",
+	},
+	{
+		name:      "A failed condition retrieval with multiple values",
+		field:     "status",
+		operator:  "in",
+		value:     "open,pending",
+		condition: "",
+		parameter: "",
+	},
 	{
 		name:      "A failed condition retrieval",
 		field:     "",
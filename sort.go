@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"strings"
+)
+
+// SortTerm is a pagination sort term structure.
+type SortTerm struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated order by into sort terms.
+// A field prefixed with a minus sign is sorted in descending order.
+// Other fields are sorted in the order direction, which defaults to ascending.
+// Returns nil when the order by is empty.
+func ParseSort(orderBy, order string) []SortTerm {
+	if orderBy == "" {
+		return nil
+	}
+
+	var terms []SortTerm
+
+	for _, field := range strings.Split(orderBy, ",") {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "-") {
+			terms = append(terms, SortTerm{Field: strings.TrimPrefix(field, "-"), Desc: true})
+		} else {
+			terms = append(terms, SortTerm{Field: field, Desc: order == "desc"})
+		}
+	}
+
+	return terms
+}
+
+// sortColumn is a resolved column and direction of a query order.
+type sortColumn struct {
+	field  string
+	column string
+	desc   bool
+}
+
+// getSortColumns returns the resolved columns and directions the query is ordered by.
+// Sort terms that are not sortable are skipped, and the schema default sort is used when none is left.
+func (query *Query) getSortColumns() []sortColumn {
+	terms := query.Sort
+	if len(terms) == 0 {
+		terms = ParseSort(query.OrderBy, query.Order)
+	}
+
+	var columns []sortColumn
+
+	for _, term := range terms {
+		if column, ok := query.Schema.GetSortColumn(term.Field); ok && term.Field != "" {
+			columns = append(columns, sortColumn{field: term.Field, column: column, desc: term.Desc})
+		}
+	}
+
+	if len(columns) == 0 {
+		columns = []sortColumn{{column: query.Schema.GetDefaultSort(), desc: query.Order == "desc"}}
+	}
+
+	if query.Schema != nil {
+		for i := range columns {
+			columns[i].column = quoteColumn(query.Schema.GetDialect(), columns[i].column)
+		}
+	}
+
+	return columns
+}
@@ -0,0 +1,81 @@
+package pagination_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yohgo/pagination"
+)
+
+// parseSortDataProvider provides data for the TestParseSort function.
+var parseSortDataProvider = []struct {
+	name    string
+	orderBy string
+	order   string
+	want    []pagination.SortTerm
+}{
+	{
+		name:    "A sort parsing with no order by",
+		orderBy: "",
+		order:   "desc",
+		want:    nil,
+	},
+	{
+		name:    "A sort parsing with a single field",
+		orderBy: "name",
+		order:   "",
+		want:    []pagination.SortTerm{{Field: "name"}},
+	},
+	{
+		name:    "A sort parsing with a single field and an order",
+		orderBy: "name",
+		order:   "desc",
+		want:    []pagination.SortTerm{{Field: "name", Desc: true}},
+	},
+	{
+		name:    "A sort parsing with multiple fields",
+		orderBy: "status,-priority, id",
+		order:   "",
+		want: []pagination.SortTerm{
+			{Field: "status"},
+			{Field: "priority", Desc: true},
+			{Field: "id"},
+		},
+	},
+	{
+		name:    "A sort parsing with multiple fields and an order",
+		orderBy: "status,-priority,id",
+		order:   "desc",
+		want: []pagination.SortTerm{
+			{Field: "status", Desc: true},
+			{Field: "priority", Desc: true},
+			{Field: "id", Desc: true},
+		},
+	},
+	{
+		name:    "A sort parsing with an empty field",
+		orderBy: "status,,id",
+		order:   "asc",
+		want: []pagination.SortTerm{
+			{Field: "status"},
+			{Field: ""},
+			{Field: "id"},
+		},
+	},
+}
+
+// TestParseSort tests the paginator ParseSort method.
+func TestParseSort(t *testing.T) {
+	t.Log("ParseSort")
+	// Check each test case
+	for _, testcase := range parseSortDataProvider {
+		t.Log(testcase.name)
+
+		got := pagination.ParseSort(testcase.orderBy, testcase.order)
+
+		// Check terms
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected terms to be %v but got %v", testcase.want, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the type of the values of a schema field.
+type FieldType string
+
+// The field types, a field without a type keeps its values as strings.
+const (
+	TypeString FieldType = "string"
+	TypeInt    FieldType = "int"
+	TypeFloat  FieldType = "float"
+	TypeBool   FieldType = "bool"
+	TypeTime   FieldType = "time"
+	TypeUUID   FieldType = "uuid"
+	TypeEnum   FieldType = "enum"
+)
+
+// timeLayouts are the layouts time values are parsed with.
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// uuidPattern matches a hyphenated UUID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Parse converts a value to the Go type of the condition field.
+// Returns an int64, float64, bool or time.Time for int, float, bool and time fields, and a string otherwise.
+// Returns an invalid value error if the value is not valid for the field type or is not one of the enum values.
+func (condition *Condition) Parse(value string) (interface{}, error) {
+	return condition.ParseAs(condition.Type, value)
+}
+
+// ParseAs converts a value to the Go type of a field type.
+// Returns an invalid value error if the value is not valid for the field type.
+func (condition *Condition) ParseAs(fieldType FieldType, value string) (interface{}, error) {
+	var parsed interface{}
+	var err error
+
+	switch fieldType {
+	case TypeInt:
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case TypeFloat:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case TypeBool:
+		parsed, err = strconv.ParseBool(value)
+	case TypeTime:
+		parsed, err = parseTime(value)
+	case TypeUUID:
+		parsed = strings.ToLower(value)
+		if !uuidPattern.MatchString(value) {
+			err = strconv.ErrSyntax
+		}
+	case TypeEnum:
+		parsed, err = value, strconv.ErrSyntax
+		for _, enum := range condition.Enum {
+			if value == enum {
+				err = nil
+			}
+		}
+	default:
+		parsed = value
+	}
+
+	if err != nil {
+		return nil, &ValidationError{
+			Parameter: condition.Parameter,
+			Value:     value,
+			Err:       ErrInvalidValue,
+			Message:   "Value '" + value + "' of '" + condition.Field + "' is not a valid " + string(fieldType),
+		}
+	}
+
+	return parsed, nil
+}
+
+// parseTime parses a time value with any of the time layouts.
+func parseTime(value string) (time.Time, error) {
+	var parsed time.Time
+	var err error
+
+	for _, layout := range timeLayouts {
+		if parsed, err = time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return parsed, err
+}
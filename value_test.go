@@ -0,0 +1,208 @@
+package pagination_test
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yohgo/pagination"
+)
+
+// typedSchema is a schema declaring a field of every type.
+var typedSchema = &pagination.Schema{
+	Fields: map[string]pagination.Field{
+		"age":     {Type: pagination.TypeInt},
+		"score":   {Type: pagination.TypeFloat},
+		"vip":     {Type: pagination.TypeBool},
+		"created": {Type: pagination.TypeTime},
+		"id":      {Type: pagination.TypeUUID},
+		"status":  {Type: pagination.TypeEnum, Enum: []string{"active", "banned"}},
+		"name":    {Type: pagination.TypeString},
+	},
+}
+
+// typedSearchDataProvider provides data for the TestTypedSearch function.
+var typedSearchDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful search creation - int value",
+		query: "age__greaterthan=18",
+		want:  &pagination.Search{SQL: "((`age` > ?))", Parameters: []interface{}{int64(18)}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - float value",
+		query: "score__lessthan=4.5",
+		want:  &pagination.Search{SQL: "((`score` < ?))", Parameters: []interface{}{4.5}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - bool value",
+		query: "vip__equals=true",
+		want:  &pagination.Search{SQL: "((`vip` = ?))", Parameters: []interface{}{true}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - time value",
+		query: "created__after=2024-01-01",
+		want:  &pagination.Search{SQL: "((`created` > ?))", Parameters: []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - uuid value",
+		query: "id__equals=0F8FAD5B-D9CB-469F-A165-70867728950E",
+		want:  &pagination.Search{SQL: "((`id` = ?))", Parameters: []interface{}{"0f8fad5b-d9cb-469f-a165-70867728950e"}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - enum values",
+		query: "status__in=active,banned",
+		want:  &pagination.Search{SQL: "((`status` IN (?, ?)))", Parameters: []interface{}{"active", "banned"}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - string value",
+		query: "name__contains=ammar",
+		want:  &pagination.Search{SQL: "((`name` LIKE ? ESCAPE '!'))", Parameters: []interface{}{"%ammar%"}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - ordered int bounds",
+		query: "age__between=9,10",
+		want:  &pagination.Search{SQL: "((`age` BETWEEN ? AND ?))", Parameters: []interface{}{int64(9), int64(10)}},
+		err:   nil,
+	},
+	{
+		name:  "Successful search creation - date part of a typed field",
+		query: "created__year=2024",
+		want:  &pagination.Search{SQL: "((YEAR(`created`) = ?))", Parameters: []interface{}{int64(2024)}},
+		err:   nil,
+	},
+	{
+		name:  "A failed search creation - invalid int value",
+		query: "age__greaterthan=abc",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "age__greaterthan", Value: "abc", Err: pagination.ErrInvalidValue, Message: "Value 'abc' of 'age' is not a valid int"},
+	},
+	{
+		name:  "A failed search creation - invalid list value",
+		query: "age__in=1,x",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "age__in", Value: "x", Err: pagination.ErrInvalidValue, Message: "Value 'x' of 'age' is not a valid int"},
+	},
+	{
+		name:  "A failed search creation - invalid bool value",
+		query: "vip__equals=maybe",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "vip__equals", Value: "maybe", Err: pagination.ErrInvalidValue, Message: "Value 'maybe' of 'vip' is not a valid bool"},
+	},
+	{
+		name:  "A failed search creation - invalid time value",
+		query: "created__before=yesterday",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "created__before", Value: "yesterday", Err: pagination.ErrInvalidValue, Message: "Value 'yesterday' of 'created' is not a valid time"},
+	},
+	{
+		name:  "A failed search creation - invalid uuid value",
+		query: "id__equals=42",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "id__equals", Value: "42", Err: pagination.ErrInvalidValue, Message: "Value '42' of 'id' is not a valid uuid"},
+	},
+	{
+		name:  "A failed search creation - unknown enum value",
+		query: "status__equals=deleted",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "status__equals", Value: "deleted", Err: pagination.ErrInvalidValue, Message: "Value 'deleted' of 'status' is not a valid enum"},
+	},
+	{
+		name:  "A failed search creation - invalid date part",
+		query: "created__month=may",
+		want:  nil,
+		err:   &pagination.ValidationError{Parameter: "created__month", Value: "may", Err: pagination.ErrInvalidValue, Message: "Value 'may' of 'created' is not a valid int"},
+	},
+}
+
+// TestTypedSearch tests the paginator NewSearch method with typed fields.
+func TestTypedSearch(t *testing.T) {
+	t.Log("NewSearch with typed fields")
+
+	// Check each test case
+	for _, testcase := range typedSearchDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewSearch(query, typedSchema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check search
+		if !reflect.DeepEqual(testcase.want, got) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got)
+		}
+	}
+}
+
+// typedKeysetDataProvider provides data for the TestTypedKeyset function.
+var typedKeysetDataProvider = []struct {
+	name  string
+	query string
+	want  *pagination.Search
+	err   error
+}{
+	{
+		name:  "Successful query creation - int after position",
+		query: "order_by=age,name&after=18&after=ammar",
+		want: &pagination.Search{
+			SQL:        "((`age` > ?) OR (`age` = ? AND `name` > ?))",
+			Parameters: []interface{}{int64(18), int64(18), "ammar"},
+		},
+		err: nil,
+	},
+	{
+		name:  "A failed query creation - invalid int before position",
+		query: "order_by=age&before=abc",
+		want:  nil,
+		err: &pagination.ValidationError{
+			Parameter: "before",
+			Value:     "abc",
+			Err:       pagination.ErrInvalidValue,
+			Message:   "Value 'abc' of 'age' is not a valid int",
+		},
+	},
+}
+
+// TestTypedKeyset tests the paginator NewQuery method with keyset positions of typed fields.
+func TestTypedKeyset(t *testing.T) {
+	t.Log("NewQuery with keyset positions of typed fields")
+
+	schema := &pagination.Schema{
+		Fields: map[string]pagination.Field{"age": {Type: pagination.TypeInt}},
+		Sorts:  map[string]string{"age": "", "name": ""},
+	}
+
+	// Check each test case
+	for _, testcase := range typedKeysetDataProvider {
+		t.Log(testcase.name)
+
+		query, _ := url.ParseQuery(testcase.query)
+		got, err := pagination.NewQuery(query, schema)
+
+		// Check error
+		if !reflect.DeepEqual(testcase.err, err) {
+			t.Errorf("Expected error to be %v but got %v", testcase.err, err)
+		}
+
+		// Check search
+		if got != nil && !reflect.DeepEqual(testcase.want, got.Search) {
+			t.Errorf("Expected search to be %v but got %v", testcase.want, got.Search)
+		}
+	}
+}